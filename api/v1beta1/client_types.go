@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultClientUsernameKey is the key of the username in the basic auth Secret of the client when it is not set
+	DefaultClientUsernameKey = "username"
+	// DefaultClientPasswordKey is the key of the password in the basic auth Secret of the client when it is not set
+	DefaultClientPasswordKey = "password"
+)
+
+// ClientSpec defines how the operator calls the MLFlow API
+// +kubebuilder:validation:XValidation:rule="!(has(self.basicAuthSecret) && has(self.tokenSecret))",message="basicAuthSecret and tokenSecret are mutually exclusive"
+type ClientSpec struct {
+	// BasicAuthSecret references the Secret holding the username and password of the MLFlow basic auth app
+	// +optional
+	BasicAuthSecret *CredentialsSecretReference `json:"basicAuthSecret,omitempty"`
+
+	// TokenSecret references the Secret key holding the bearer token sent to the MLFlow API
+	// +optional
+	TokenSecret *corev1.SecretKeySelector `json:"tokenSecret,omitempty"`
+
+	// CABundleSecret references the Secret key holding the CA bundle the MLFlow API is verified with
+	// +optional
+	CABundleSecret *corev1.SecretKeySelector `json:"caBundleSecret,omitempty"`
+
+	// URL of the MLFlow server, e.g. https://mlflow.example.com, defaults to the Service of the MLFlow server
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	URL string `json:"url,omitempty"`
+}
@@ -0,0 +1,43 @@
+package v1beta1
+
+// Phase is a summary of the conditions of an MLFlow instance
+// +kubebuilder:validation:Enum=Pending;Progressing;Ready;Degraded
+type Phase string
+
+const (
+	PhasePending     Phase = "Pending"
+	PhaseProgressing Phase = "Progressing"
+	PhaseReady       Phase = "Ready"
+	PhaseDegraded    Phase = "Degraded"
+)
+
+const (
+	// ConditionTypeReady is true when the MLflow server is available
+	ConditionTypeReady = "Ready"
+	// ConditionTypeProgressing is true while the MLflow server is rolling out
+	ConditionTypeProgressing = "Progressing"
+	// ConditionTypeDegraded is true when the MLFlow instance cannot be reconciled to its desired state
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeModelSyncHealthy is true when the last model sync deployed every model without errors
+	ConditionTypeModelSyncHealthy = "ModelSyncHealthy"
+	// ConditionTypeBackendReachable is true when the operator can reach the MLflow server API
+	ConditionTypeBackendReachable = "BackendReachable"
+)
+
+const (
+	ReasonBackendStoreSecretNotFound = "BackendStoreSecretNotFound"
+	ReasonConfigMapNotFound          = "ConfigMapNotFound"
+	ReasonInvalidConfiguration       = "InvalidConfiguration"
+	ReasonMLFlowNotFound             = "MLFlowNotFound"
+	ReasonDeploymentFailed           = "DeploymentFailed"
+	ReasonServiceFailed              = "ServiceFailed"
+	ReasonIngressFailed              = "IngressFailed"
+	ReasonDeploymentNotReady         = "DeploymentNotReady"
+	ReasonDeploymentReady            = "DeploymentReady"
+	ReasonReconciled                 = "Reconciled"
+	ReasonModelSyncSucceeded         = "ModelSyncSucceeded"
+	ReasonModelSyncFailed            = "ModelSyncFailed"
+	ReasonServerReachable            = "ServerReachable"
+	ReasonServerUnreachable          = "ServerUnreachable"
+	ReasonModelAliasNotResolved      = "ModelAliasNotResolved"
+)
@@ -0,0 +1,72 @@
+package v1beta1
+
+// IngressKind is the kind of the object routing external traffic to the MLFlow server and its models
+// +kubebuilder:validation:Enum=Ingress;HTTPRoute
+type IngressKind string
+
+const (
+	IngressKindIngress   IngressKind = "Ingress"
+	IngressKindHTTPRoute IngressKind = "HTTPRoute"
+)
+
+// ModelRouting is how the served models are routed
+// +kubebuilder:validation:Enum=Path;Host
+type ModelRouting string
+
+const (
+	// ModelRoutingPath routes /models/<name>/<version or alias> to the model, stripping the prefix
+	ModelRoutingPath ModelRouting = "Path"
+	// ModelRoutingHost routes <deployment name>.<host> to the model
+	ModelRoutingHost ModelRouting = "Host"
+)
+
+// IngressSpec defines the Ingress or Gateway API HTTPRoute of the MLFlow server and its models
+// +kubebuilder:validation:XValidation:rule="self.kind != 'HTTPRoute' || size(self.parentRefs) > 0",message="parentRefs is required for HTTPRoute"
+// +kubebuilder:validation:XValidation:rule="self.kind != 'HTTPRoute' || !has(self.modelRouting) || self.modelRouting != 'Host'",message="host based model routing is only supported for Ingress"
+// +kubebuilder:validation:XValidation:rule="!has(self.modelRouting) || self.modelRouting != 'Host' || has(self.host)",message="host is required for host based model routing"
+type IngressSpec struct {
+	// Annotations added to the generated object
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ClassName is the IngressClass of the Ingress
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// Kind of the generated object
+	// +kubebuilder:default=Ingress
+	// +optional
+	Kind IngressKind `json:"kind,omitempty"`
+
+	// Host the MLFlow UI and API are served on
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// TLSSecretName is the Secret holding the certificate of the Ingress hosts
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// ModelRouting is how the served models are routed, defaults to Path. The path routed models of an Ingress
+	// are served by a separate <name>-models Ingress using the ingress-nginx rewrite annotations to strip the prefix.
+	// +optional
+	ModelRouting ModelRouting `json:"modelRouting,omitempty"`
+
+	// ParentRefs are the Gateways the HTTPRoute attaches to
+	// +optional
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+}
+
+// GatewayParentReference references a Gateway listener
+type GatewayParentReference struct {
+	// Name of the Gateway
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the Gateway, defaults to the namespace of the MLFlow instance
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the listener of the Gateway
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
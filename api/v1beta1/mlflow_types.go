@@ -13,22 +13,409 @@ type MLFlowSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// BackendStore is the database the MLFlow server keeps experiments, runs and registered models in
+	// +optional
+	BackendStore *BackendStoreSpec `json:"backendStore,omitempty"`
+
+	// ArtifactStore is where the MLFlow server stores run artifacts and models
+	// +optional
+	ArtifactStore *ArtifactStoreSpec `json:"artifactStore,omitempty"`
+
+	// Server overrides the pods of the MLFlow server
+	// +optional
+	Server *ServerSpec `json:"server,omitempty"`
+
+	// ModelDefaults overrides the pods of every served model
+	// +optional
+	ModelDefaults *ModelDefaultsSpec `json:"modelDefaults,omitempty"`
+
+	// ModelLimits are the ceilings of the model container requests and limits, higher values are clamped
+	// +optional
+	ModelLimits corev1.ResourceList `json:"modelLimits,omitempty"`
+
+	// Service configures how the MLFlow server is exposed
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
+	// Client configures the authentication and TLS of the MLFlow API calls of the operator
+	// +optional
+	Client *ClientSpec `json:"client,omitempty"`
+
+	// Ingress exposes the MLFlow UI, API and the served models outside the cluster
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+
+	// ModelSelection restricts the registered model versions served by the operator, every version is served when it is not set
+	// +optional
+	ModelSelection *ModelSelectionSpec `json:"modelSelection,omitempty"`
+
+	// ModelSync configures how the model registry is scanned
+	// +optional
+	ModelSync *ModelSyncSpec `json:"modelSync,omitempty"`
+
+	// ModelPromotion replaces the version served by an alias only once the new version is ready,
+	// the Deployment is updated in place when it is not set
+	// +optional
+	ModelPromotion *ModelPromotionSpec `json:"modelPromotion,omitempty"`
+
+	// ModelGarbageCollection configures the deletion of model deployments whose model version is no longer served,
+	// orphan model deployments are deleted without a grace period when it is not set
+	// +optional
+	ModelGarbageCollection *ModelGarbageCollectionSpec `json:"modelGarbageCollection,omitempty"`
+
 	// Image of the MLFlow server
 	Image string `json:"image,omitempty"`
 
 	// Image of the MLFlow model
 	ModelImage string `json:"modelImage,omitempty"`
 
-	// Name of the ConfigMap for MLFlowSpec's configuration
+	// Name of the ConfigMap for MLFlowSpec's configuration, injected into the server as environment variables.
+	// The server.workers, server.gunicornOpts, server.staticPrefix, server.appName and server.exposePrometheus keys
+	// are passed to the server as flags.
 	// +kubebuilder:validation:MinLength=1
 	ConfigMapName string `json:"configMapName"`
 
-	// Image of the MLFlow model
+	// ModelImageAllowlist are the images model versions may select with the image tag.
+	// An entry ending with * allows every image starting with the rest of the entry.
+	// +optional
+	ModelImageAllowlist []string `json:"modelImageAllowlist,omitempty"`
+
+	// ModelSyncPeriodInMinutes is the interval between two model syncs, defaults to 1 minute
+	// +kubebuilder:validation:Minimum=0
+	// +optional
 	ModelSyncPeriodInMinutes int `json:"modelSyncPeriodInMinutes,omitempty"`
 
 	// Quantity of instances
 	// +kubebuilder:validation:Minimum=1
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// ModelPort is the port the model servers listen on and their Services expose
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=5000
+	// +optional
+	ModelPort int32 `json:"modelPort,omitempty"`
+}
+
+// BackendStoreType is the database engine of the MLFlow backend store
+// +kubebuilder:validation:Enum=postgresql;mysql;mssql;sqlite
+type BackendStoreType string
+
+const (
+	BackendStorePostgreSQL BackendStoreType = "postgresql"
+	BackendStoreMySQL      BackendStoreType = "mysql"
+	BackendStoreMSSQL      BackendStoreType = "mssql"
+	BackendStoreSQLite     BackendStoreType = "sqlite"
+)
+
+// BackendStoreSpec defines the database connection of the MLFlow server
+type BackendStoreSpec struct {
+	// CredentialsSecret references the Secret holding the database username and password, not used for sqlite.
+	// The values are inserted into the database URI as they are, so the characters reserved in URIs such as
+	// @ : / ? # and % have to be percent-encoded in the Secret.
+	// +optional
+	CredentialsSecret *CredentialsSecretReference `json:"credentialsSecret,omitempty"`
+
+	// Type of the database
+	Type BackendStoreType `json:"type"`
+
+	// Driver of the SQLAlchemy dialect, e.g. pymysql for mysql+pymysql
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// Host of the database server, not used for sqlite
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Database name, or the database file path for sqlite
+	// +kubebuilder:validation:MinLength=1
+	Database string `json:"database"`
+
+	// Port of the database server, defaults to the well known port of the database type
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// CredentialsSecretReference references a Secret in the namespace of the MLFlow instance
+type CredentialsSecretReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// UsernameKey is the key of the username in the Secret
+	// +kubebuilder:default=username
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the password in the Secret
+	// +kubebuilder:default=password
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// ArtifactStoreSpec defines the artifact destination of the MLFlow server, exactly one store must be set
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type ArtifactStoreSpec struct {
+	// S3 stores artifacts in an S3 compatible bucket such as AWS S3 or MinIO
+	// +optional
+	S3 *S3ArtifactStore `json:"s3,omitempty"`
+
+	// GCS stores artifacts in a Google Cloud Storage bucket
+	// +optional
+	GCS *GCSArtifactStore `json:"gcs,omitempty"`
+
+	// AzureBlob stores artifacts in an Azure Blob Storage container
+	// +optional
+	AzureBlob *AzureBlobArtifactStore `json:"azureBlob,omitempty"`
+
+	// PVC stores artifacts on a PersistentVolumeClaim mounted into the MLFlow server
+	// +optional
+	PVC *PVCArtifactStore `json:"pvc,omitempty"`
+}
+
+// S3ArtifactStore defines an S3 compatible artifact store
+type S3ArtifactStore struct {
+	// CredentialsSecret references the Secret holding the access keys, the default AWS credential chain is used if not set
+	// +optional
+	CredentialsSecret *AccessKeySecretReference `json:"credentialsSecret,omitempty"`
+
+	// CABundleSecret references the Secret key holding the CA bundle of the endpoint
+	// +optional
+	CABundleSecret *corev1.SecretKeySelector `json:"caBundleSecret,omitempty"`
+
+	// Bucket name
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Path prefix inside the bucket
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Endpoint URL of the S3 compatible server, e.g. https://minio.minio.svc.cluster.local
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region of the bucket
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// InsecureSkipTLSVerify disables TLS verification of the endpoint
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// AccessKeySecretReference references a Secret holding an access key pair
+type AccessKeySecretReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// AccessKeyIDKey is the key of the access key id in the Secret
+	// +kubebuilder:default=accessKeyId
+	// +optional
+	AccessKeyIDKey string `json:"accessKeyIdKey,omitempty"`
+
+	// SecretAccessKeyKey is the key of the secret access key in the Secret
+	// +kubebuilder:default=secretAccessKey
+	// +optional
+	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
+}
+
+// GCSArtifactStore defines a Google Cloud Storage artifact store
+type GCSArtifactStore struct {
+	// CredentialsSecret references the Secret key holding the service account JSON key, workload identity is used if not set
+	// +optional
+	CredentialsSecret *corev1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+
+	// Bucket name
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Path prefix inside the bucket
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// AzureBlobArtifactStore defines an Azure Blob Storage artifact store
+type AzureBlobArtifactStore struct {
+	// ConnectionStringSecret references the Secret key holding the storage account connection string
+	// +optional
+	ConnectionStringSecret *corev1.SecretKeySelector `json:"connectionStringSecret,omitempty"`
+
+	// AccessKeySecret references the Secret key holding the storage account access key
+	// +optional
+	AccessKeySecret *corev1.SecretKeySelector `json:"accessKeySecret,omitempty"`
+
+	// Container name
+	// +kubebuilder:validation:MinLength=1
+	Container string `json:"container"`
+
+	// StorageAccount name
+	// +kubebuilder:validation:MinLength=1
+	StorageAccount string `json:"storageAccount"`
+
+	// Path prefix inside the container
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// PVCArtifactStore defines an artifact store on a PersistentVolumeClaim
+type PVCArtifactStore struct {
+	// ClaimName of the PersistentVolumeClaim
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+
+	// MountPath of the volume in the MLFlow server container
+	// +kubebuilder:default=/mlartifacts
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// SubPath inside the volume
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+}
+
+// ServiceSpec defines the Service of the MLFlow server
+// +kubebuilder:validation:XValidation:rule="!has(self.nodePort) || self.type != 'ClusterIP'",message="nodePort requires a NodePort or LoadBalancer service"
+type ServiceSpec struct {
+	// Annotations added to the Service
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Type of the Service
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default=ClusterIP
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IPs of a LoadBalancer Service
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// Port of the Service
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=5000
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// NodePort of a NodePort or LoadBalancer Service, allocated by the cluster when it is not set
+	// +kubebuilder:validation:Minimum=30000
+	// +kubebuilder:validation:Maximum=32767
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+// ModelStage is a stage of the MLflow model registry
+// +kubebuilder:validation:Enum=None;Staging;Production;Archived
+type ModelStage string
+
+const (
+	ModelStageNone       ModelStage = "None"
+	ModelStageStaging    ModelStage = "Staging"
+	ModelStageProduction ModelStage = "Production"
+	ModelStageArchived   ModelStage = "Archived"
+)
+
+// ModelSelectionSpec defines which registered model versions are served.
+// Name patterns, stages and tag are applied first, LatestVersions keeps the highest matching versions of each model.
+// Archived versions are only served when the Archived stage is selected.
+type ModelSelectionSpec struct {
+	// Tag only selects the versions carrying the tag
+	// +optional
+	Tag *ModelTagSelector `json:"tag,omitempty"`
+
+	// LatestVersions is the number of the latest versions served per model
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LatestVersions *int32 `json:"latestVersions,omitempty"`
+
+	// Stages only selects the latest version of each model in one of the stages,
+	// versions in the Archived stage are not selected when it is not set
+	// +optional
+	Stages []ModelStage `json:"stages,omitempty"`
+
+	// Include only selects the models whose name matches one of the regular expressions
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude skips the models whose name matches one of the regular expressions
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// ModelSyncSpec defines how the model syncs scan the model registry
+type ModelSyncSpec struct {
+	// FullResyncPeriodInMinutes is the interval between two syncs reconsidering every model version,
+	// the syncs in between only deploy the model versions updated in the registry. Defaults to 60 minutes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FullResyncPeriodInMinutes int32 `json:"fullResyncPeriodInMinutes,omitempty"`
+
+	// Concurrency is the number of concurrent MLflow API calls of a model sync, defaults to 4
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// +optional
+	Concurrency int32 `json:"concurrency,omitempty"`
+}
+
+// ModelPromotionSpec defines how a new model version replaces the version served by an alias. Every version is served by
+// its own Deployment and the Service of the alias is switched over once the new version is ready, scaling down the old one.
+type ModelPromotionSpec struct {
+	// Canary shifts the traffic of the alias to the new version in steps before it is promoted,
+	// the mlflowOperator-canaryWeight tag of the model version sets the weight of the first step,
+	// the configured weights above it follow
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// ReadinessTimeoutInMinutes a new model version has to become ready before its promotion fails, defaults to 10 minutes
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReadinessTimeoutInMinutes int32 `json:"readinessTimeoutInMinutes,omitempty"`
+}
+
+// CanarySpec defines the traffic steps of a new model version. The traffic is split by the weights of the HTTPRoute
+// backends when the ingress is an HTTPRoute, otherwise by the replicas of the versions behind the Service of the alias.
+// With an HTTPRoute, the canary only applies to the traffic of the gateway, the in-cluster traffic sent to the Service
+// of the alias reaches the serving version until the new version is promoted.
+type CanarySpec struct {
+	// Weights are the percentages of the traffic sent to the new version at every step, it is promoted after the last one.
+	// Defaults to 10 and 50.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:XValidation:rule="self.all(weight, weight >= 1 && weight <= 99)",message="weights must be between 1 and 99"
+	// +optional
+	Weights []int32 `json:"weights,omitempty"`
+
+	// StepIntervalInMinutes the new version serves every weight before the next step, defaults to 5 minutes
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StepIntervalInMinutes int32 `json:"stepIntervalInMinutes,omitempty"`
+}
+
+// ModelGarbageCollectionSpec defines how orphan model deployments are deleted
+type ModelGarbageCollectionSpec struct {
+	// GracePeriodInMinutes an orphan model deployment keeps running before it is deleted
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodInMinutes int `json:"gracePeriodInMinutes,omitempty"`
+
+	// DryRun marks and logs orphan model deployments without deleting them, defaults to false
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ModelTagSelector matches a model version tag
+type ModelTagSelector struct {
+	// Key of the tag
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value of the tag, any value matches when it is not set
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // MLFlowStatus defines the observed state of MLFlow
@@ -36,23 +423,150 @@ type MLFlowStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// ActiveModels is the active instances of the MLflow model deployments
-	ActiveModels map[string]corev1.ObjectReference `json:"activeModels,omitempty"`
+	// Models is the serving state of every model version deployed by the operator
+	// +listType=map
+	// +listMapKey=deploymentName
+	// +optional
+	Models []ModelStatus `json:"models,omitempty"`
+
+	// LastModelSyncTime is the time the registered models were last synced from the MLflow server
+	// +optional
+	LastModelSyncTime *metav1.Time `json:"lastModelSyncTime,omitempty"`
 
 	// Active is the active instance of the MLflow server deployment
 	// +optional
 	Active corev1.ObjectReference `json:"active,omitempty"`
+
+	// Phase summarizes the conditions of the MLFlow instance
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the MLFlow instance
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the MLFlow instance reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ReadyReplicas is the number of ready MLflow server pods
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// ServedModelCount is the number of model versions served by the operator
+	// +optional
+	ServedModelCount int32 `json:"servedModelCount,omitempty"`
+}
+
+// ModelPromotionPhase is the phase of the promotion of a model version
+// +kubebuilder:validation:Enum=Progressing;Canary;Failed
+type ModelPromotionPhase string
+
+const (
+	ModelPromotionProgressing ModelPromotionPhase = "Progressing"
+	ModelPromotionCanary      ModelPromotionPhase = "Canary"
+	ModelPromotionFailed      ModelPromotionPhase = "Failed"
+)
+
+// ModelPromotionStatus is the state of the promotion of a model version
+type ModelPromotionStatus struct {
+	// StartedAt is the time the Deployment of the model version was first found not ready
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// LastStepTime is the time the canary traffic weight of the model version last changed
+	// +optional
+	LastStepTime metav1.Time `json:"lastStepTime,omitempty"`
+
+	// Version of the registered model being promoted
+	Version string `json:"version"`
+
+	// Message is why the promotion failed
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// DeploymentName is the name of the Deployment of the promoted model version
+	// +optional
+	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// Phase of the promotion
+	Phase ModelPromotionPhase `json:"phase"`
+
+	// LastUpdatedTimestamp is the last_updated_timestamp of the model version in the registry when its promotion failed,
+	// the promotion is retried once the model version is updated
+	// +optional
+	LastUpdatedTimestamp int64 `json:"lastUpdatedTimestamp,omitempty"`
+
+	// Weight is the percentage of the traffic sent to the model version during its canary
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// ModelStatus is the serving state of a registered model version
+type ModelStatus struct {
+	// LastTransitionTime is the last time the serving state of the model version changed
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Promotion is the state of the promotion of the model version, it is cleared once the version is promoted
+	// +optional
+	Promotion *ModelPromotionStatus `json:"promotion,omitempty"`
+
+	// Tags are the operator tags of the model version applied to its deployment
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Name of the registered model
+	Name string `json:"name"`
+
+	// Version of the registered model
+	Version string `json:"version"`
+
+	// Stage of the model version
+	// +optional
+	Stage string `json:"stage,omitempty"`
+
+	// Alias of the registered model pointing to the model version
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// DeploymentName is the name of the Deployment serving the model version
+	DeploymentName string `json:"deploymentName"`
+
+	// ServingDeploymentName is the name of the Deployment selected by the Service of a promoted model
+	// +optional
+	ServingDeploymentName string `json:"servingDeploymentName,omitempty"`
+
+	// Endpoint is the in-cluster URL of the model version
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// LastError is the last error occurred while deploying the model version
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Replicas is the desired number of model pods
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of ready model pods
+	ReadyReplicas int32 `json:"readyReplicas"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Ready Replicas",type=integer,JSONPath=`.status.readyReplicas`
+//+kubebuilder:printcolumn:name="Models",type=integer,JSONPath=`.status.servedModelCount`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // MLFlow is the Schema for the mlflows API
 type MLFlow struct {
-	Status            MLFlowStatus `json:"status,omitempty"`
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              MLFlowSpec `json:"spec,omitempty"`
+	Spec              MLFlowSpec   `json:"spec,omitempty"`
+	Status            MLFlowStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,129 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnvManager is the tool used by mlflow models serve to restore the model environment
+// +kubebuilder:validation:Enum=local;virtualenv;conda
+type EnvManager string
+
+const (
+	EnvManagerLocal      EnvManager = "local"
+	EnvManagerVirtualenv EnvManager = "virtualenv"
+	EnvManagerConda      EnvManager = "conda"
+)
+
+// ServingRuntime is the server mlflow models serve runs the model with
+// +kubebuilder:validation:Enum=mlflow;mlserver
+type ServingRuntime string
+
+const (
+	// ServingRuntimeMLflow serves the model with the MLflow scoring server
+	ServingRuntimeMLflow ServingRuntime = "mlflow"
+	// ServingRuntimeMLServer serves the model with MLServer and the V2 inference protocol,
+	// the model image must have the mlserver and mlserver-mlflow packages installed
+	ServingRuntimeMLServer ServingRuntime = "mlserver"
+)
+
+// MLflowModelDeploymentSpec defines the desired state of MLflowModelDeployment
+// +kubebuilder:validation:XValidation:rule="has(self.version) != has(self.alias)",message="exactly one of version or alias must be set"
+type MLflowModelDeploymentSpec struct {
+	// Replicas is the quantity of model pods
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// MLFlowRef references the MLFlow instance in the same namespace whose registry holds the model
+	MLFlowRef corev1.LocalObjectReference `json:"mlflowRef"`
+
+	// ModelName is the name of the registered model
+	// +kubebuilder:validation:MinLength=1
+	ModelName string `json:"modelName"`
+
+	// Version of the registered model to serve
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Alias of the registered model to serve, e.g. champion
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// EnvManager is used to restore the model environment, defaults to the envManager of the modelDefaults
+	// of the MLFlow instance, then conda
+	// +optional
+	EnvManager EnvManager `json:"envManager,omitempty"`
+
+	// Runtime serving the model, defaults to the runtime of the modelDefaults of the MLFlow instance, then mlflow
+	// +optional
+	Runtime ServingRuntime `json:"runtime,omitempty"`
+
+	// Image of the model server, defaults to the modelImage of the MLFlow instance
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources of the model container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// MLflowModelDeploymentStatus defines the observed state of MLflowModelDeployment
+type MLflowModelDeploymentStatus struct {
+	// DeploymentName is the name of the Deployment serving the model
+	// +optional
+	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// Version is the model version served, resolved from the alias when the model is served by alias
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Conditions represent the latest available observations of the model deployment
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Replicas is the desired number of model pods
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of ready model pods
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="MLFlow",type=string,JSONPath=`.spec.mlflowRef.name`
+//+kubebuilder:printcolumn:name="Model",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Alias",type=string,JSONPath=`.spec.alias`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// MLflowModelDeployment is the Schema for the mlflowmodeldeployments API
+type MLflowModelDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MLflowModelDeploymentSpec   `json:"spec,omitempty"`
+	Status            MLflowModelDeploymentStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MLflowModelDeploymentList contains a list of MLflowModelDeployment
+type MLflowModelDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MLflowModelDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MLflowModelDeployment{}, &MLflowModelDeploymentList{})
+}
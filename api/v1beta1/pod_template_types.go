@@ -0,0 +1,88 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ServerSpec defines the overrides of the MLFlow server pods
+type ServerSpec struct {
+	// PodTemplate is merged into the pod template of the MLFlow server Deployment
+	// +optional
+	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
+
+	// Probes overrides the timings of the MLFlow server probes
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+}
+
+// ModelDefaultsSpec defines the overrides applied to every model pod
+type ModelDefaultsSpec struct {
+	// PodTemplate is merged into the pod template of the model Deployments
+	// +optional
+	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
+
+	// Probes overrides the timings of the model probes, overridden by the probe tags of the model version
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// Resources of the model container, overridden by the resource tags of the model version
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// EnvManager used to restore the model environments, overridden by the envManager tag of the model version.
+	// Defaults to conda.
+	// +optional
+	EnvManager EnvManager `json:"envManager,omitempty"`
+
+	// Runtime serving the models, overridden by the runtime tag of the model version. Defaults to mlflow.
+	// +optional
+	Runtime ServingRuntime `json:"runtime,omitempty"`
+}
+
+// PodTemplateSpec is the subset of the pod template which can be set on the generated Deployments.
+// Labels and annotations are added to the generated ones, which take precedence on conflicts.
+type PodTemplateSpec struct {
+	// Labels added to the pods
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the pods
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// NodeSelector of the pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity of the pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// SecurityContext of the pods
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext is set on every container of the pods
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// ServiceAccountName the pods run as
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// PriorityClassName of the pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Tolerations of the pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints of the pods
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// ImagePullSecrets used to pull the images of the pods
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
@@ -0,0 +1,39 @@
+package v1beta1
+
+// ProbesSpec overrides the timings of the generated readiness, liveness and startup probes
+type ProbesSpec struct {
+	// Readiness overrides the timings of the readiness probe
+	// +optional
+	Readiness *ProbeTimings `json:"readiness,omitempty"`
+
+	// Liveness overrides the timings of the liveness probe
+	// +optional
+	Liveness *ProbeTimings `json:"liveness,omitempty"`
+
+	// Startup overrides the timings of the startup probe
+	// +optional
+	Startup *ProbeTimings `json:"startup,omitempty"`
+}
+
+// ProbeTimings are the timings of a probe, unset values keep the operator defaults
+type ProbeTimings struct {
+	// InitialDelaySeconds before the probe is started
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds between two probes
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds after which the probe times out
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures after which the probe fails
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
@@ -42,12 +42,15 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var debug bool
+	var updateModelDescriptions bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&debug, "debug", false, "Enable debug mode")
+	flag.BoolVar(&updateModelDescriptions, "update-model-descriptions", false,
+		"Write the deployment messages to the registered model descriptions in addition to the model version tags.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -82,19 +85,34 @@ func main() {
 
 	httpClient := util.NewHTTPClient()
 
-	if err = (&controller.MLFlowReconciler{
-		K8sClient:  mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		HTTPClient: httpClient,
-		Debug:      debug,
+	mlflowReconciler := &controller.MLFlowReconciler{
+		K8sClient:               mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		HTTPClient:              httpClient,
+		Recorder:                mgr.GetEventRecorderFor("mlflow-controller"),
+		Debug:                   debug,
+		UpdateModelDescriptions: updateModelDescriptions,
 		MlflowObjectManager: &mlflow.ObjectManager{
 			Scheme: mgr.GetScheme(),
 			Debug:  debug,
 		},
-	}).SetupWithManager(mgr); err != nil {
+	}
+	if err = mlflowReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MLFlow")
 		os.Exit(1)
 	}
+	if err = (&controller.MLflowModelDeploymentReconciler{
+		K8sClient:     mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		MlflowClients: mlflowReconciler,
+		MlflowObjectManager: &mlflow.ObjectManager{
+			Scheme: mgr.GetScheme(),
+			Debug:  debug,
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MLflowModelDeployment")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
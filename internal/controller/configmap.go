@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	configMapNameField = ".spec.configMapName"
+)
+
+func (r *MLFlowReconciler) GetMLFlowConfigMap(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	namespacedName := types.NamespacedName{
+		Name:      mlflowServerConfig.Spec.ConfigMapName,
+		Namespace: mlflowServerConfig.Namespace,
+	}
+	if err := r.K8sClient.Get(ctx, namespacedName, configMap); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
+
+func indexMLFlowByConfigMapName(obj client.Object) []string {
+	mlflowServerConfig, ok := obj.(*mlflowv1beta1.MLFlow)
+	if !ok || mlflowServerConfig.Spec.ConfigMapName == "" {
+		return nil
+	}
+	return []string{mlflowServerConfig.Spec.ConfigMapName}
+}
+
+// findMLFlowsForConfigMap maps a ConfigMap event to the MLFlow instances referencing it
+func (r *MLFlowReconciler) findMLFlowsForConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	mlflowList := &mlflowv1beta1.MLFlowList{}
+	err := r.K8sClient.List(ctx, mlflowList,
+		client.InNamespace(configMap.GetNamespace()),
+		client.MatchingFields{configMapNameField: configMap.GetName()},
+	)
+	if err != nil {
+		logger.Error(err, "unable to list MLFlow instances for ConfigMap", "ConfigMap", configMap.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(mlflowList.Items))
+	for _, item := range mlflowList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+		})
+	}
+	return requests
+}
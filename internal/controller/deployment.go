@@ -6,17 +6,33 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func (r *MLFlowReconciler) CreateOrUpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+func (r *MLFlowReconciler) CreateOrUpdateDeployment(ctx context.Context, owner metav1.Object, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	return createOrUpdateDeployment(ctx, r.K8sClient, owner, deployment)
+}
+
+func (r *MLFlowReconciler) getMLFlowDeployment(ctx context.Context, name string, namespace string, deployment *appsv1.Deployment) error {
+	return getDeployment(ctx, r.K8sClient, name, namespace, deployment)
+}
+
+func (r *MLFlowReconciler) DeploymentIsNotReady(deployment *appsv1.Deployment) bool {
+	return deploymentIsNotReady(deployment)
+}
+
+// createOrUpdateDeployment creates the deployment or updates the existing one, refusing to take over a deployment
+// which is not controlled by the owner
+func createOrUpdateDeployment(ctx context.Context, k8sClient client.Client, owner metav1.Object, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
 	logger := log.FromContext(ctx)
 	existDeployment := &appsv1.Deployment{}
-	err := r.getMLFlowDeployment(ctx, deployment.Name, deployment.Namespace, existDeployment)
+	err := getDeployment(ctx, k8sClient, deployment.Name, deployment.Namespace, existDeployment)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			err = r.K8sClient.Create(ctx, deployment)
+			err = k8sClient.Create(ctx, deployment)
 			if err != nil {
 				return nil, err
 			}
@@ -25,31 +41,37 @@ func (r *MLFlowReconciler) CreateOrUpdateDeployment(ctx context.Context, deploym
 		return nil, err
 	}
 
-	if r.isThereAnyChangeOnDeployment(deployment, existDeployment) {
+	if !metav1.IsControlledBy(existDeployment, owner) {
+		return nil, notControlledError("deployment", existDeployment, owner)
+	}
+
+	if isThereAnyChangeOnDeployment(deployment, existDeployment) {
 		logger.Info("Updating Deployment")
 		existDeployment.Spec = deployment.Spec
-		err := r.K8sClient.Update(ctx, existDeployment)
+		existDeployment.Labels = mergeStringMaps(existDeployment.Labels, deployment.Labels)
+		err := k8sClient.Update(ctx, existDeployment)
 		if err != nil {
 			return nil, err
 		}
-		return deployment, nil
+		return existDeployment, nil
 	}
 
 	return existDeployment, nil
 }
 
-func (r *MLFlowReconciler) getMLFlowDeployment(ctx context.Context, name string, namespace string, deployment *appsv1.Deployment) error {
+func getDeployment(ctx context.Context, k8sClient client.Client, name string, namespace string, deployment *appsv1.Deployment) error {
 	namespacedName := types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}
-	return r.K8sClient.Get(ctx, namespacedName, deployment)
+	return k8sClient.Get(ctx, namespacedName, deployment)
 }
 
-func (r *MLFlowReconciler) DeploymentIsNotReady(deployment *appsv1.Deployment) bool {
+func deploymentIsNotReady(deployment *appsv1.Deployment) bool {
 	return *deployment.Spec.Replicas != deployment.Status.ReadyReplicas
 }
 
-func (r *MLFlowReconciler) isThereAnyChangeOnDeployment(oldDeployment *appsv1.Deployment, currentDeployment *appsv1.Deployment) bool {
-	return !equality.Semantic.DeepDerivative(oldDeployment.Spec, currentDeployment.Spec)
+func isThereAnyChangeOnDeployment(oldDeployment *appsv1.Deployment, currentDeployment *appsv1.Deployment) bool {
+	return !equality.Semantic.DeepDerivative(oldDeployment.Spec, currentDeployment.Spec) ||
+		!equality.Semantic.DeepDerivative(oldDeployment.Labels, currentDeployment.Labels)
 }
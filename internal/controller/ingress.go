@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ReconcileIngress maintains the Ingress or HTTPRoute of the MLFlow instance with a route for every served model,
+// deleting the generated objects which are no longer configured
+func (r *MLFlowReconciler) ReconcileIngress(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) error {
+	ingressSpec := mlflowServerConfig.Spec.Ingress
+	if ingressSpec == nil {
+		if err := r.deleteIngresses(ctx, mlflowServerConfig); err != nil {
+			return err
+		}
+		return r.deleteHTTPRoute(ctx, mlflowServerConfig)
+	}
+
+	routes := r.MlflowObjectManager.CreateModelRoutes(mlflowServerConfig, mlflowServerConfig.Status.Models)
+
+	if ingressSpec.Kind == mlflowv1beta1.IngressKindHTTPRoute {
+		httpRoute, err := r.MlflowObjectManager.CreateMlflowHTTPRouteObject(mlflowServerConfig, routes)
+		if err != nil {
+			return err
+		}
+		if err := r.createOrUpdateHTTPRoute(ctx, mlflowServerConfig, httpRoute); err != nil {
+			return err
+		}
+		return r.deleteIngresses(ctx, mlflowServerConfig)
+	}
+
+	ingress, err := r.MlflowObjectManager.CreateMlflowIngressObject(mlflowServerConfig, routes)
+	if err != nil {
+		return err
+	}
+	if err := r.createOrUpdateIngress(ctx, mlflowServerConfig, ingress); err != nil {
+		return err
+	}
+
+	modelIngress, err := r.MlflowObjectManager.CreateMlflowModelIngressObject(mlflowServerConfig, routes)
+	if err != nil {
+		return err
+	}
+	if modelIngress == nil {
+		err = r.deleteIngress(ctx, mlflowServerConfig, mlflow.ModelIngressName(mlflowServerConfig))
+	} else {
+		err = r.createOrUpdateIngress(ctx, mlflowServerConfig, modelIngress)
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.deleteHTTPRoute(ctx, mlflowServerConfig)
+}
+
+// createOrUpdateIngress creates the Ingress or updates the existing one, refusing to take over an Ingress
+// which is not controlled by the MLFlow instance
+func (r *MLFlowReconciler) createOrUpdateIngress(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, ingress *networkingv1.Ingress) error {
+	logger := log.FromContext(ctx)
+	existIngress := &networkingv1.Ingress{}
+	err := r.K8sClient.Get(ctx, types.NamespacedName{Name: ingress.Name, Namespace: ingress.Namespace}, existIngress)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r.K8sClient.Create(ctx, ingress)
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(existIngress, mlflowServerConfig) {
+		return notControlledError("ingress", existIngress, mlflowServerConfig)
+	}
+
+	if !equality.Semantic.DeepDerivative(ingress.Spec, existIngress.Spec) ||
+		!equality.Semantic.DeepDerivative(ingress.Annotations, existIngress.Annotations) {
+		logger.Info("Updating Ingress")
+		existIngress.Spec = ingress.Spec
+		existIngress.Annotations = mergeStringMaps(existIngress.Annotations, ingress.Annotations)
+		return r.K8sClient.Update(ctx, existIngress)
+	}
+
+	return nil
+}
+
+// createOrUpdateHTTPRoute creates the HTTPRoute or updates the existing one, refusing to take over an HTTPRoute
+// which is not controlled by the MLFlow instance
+func (r *MLFlowReconciler) createOrUpdateHTTPRoute(
+	ctx context.Context,
+	mlflowServerConfig *mlflowv1beta1.MLFlow,
+	httpRoute *unstructured.Unstructured,
+) error {
+	logger := log.FromContext(ctx)
+	existHTTPRoute := &unstructured.Unstructured{}
+	existHTTPRoute.SetGroupVersionKind(httpRoute.GroupVersionKind())
+	err := r.K8sClient.Get(ctx, client.ObjectKeyFromObject(httpRoute), existHTTPRoute)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r.K8sClient.Create(ctx, httpRoute)
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(existHTTPRoute, mlflowServerConfig) {
+		return notControlledError("httproute", existHTTPRoute, mlflowServerConfig)
+	}
+
+	if !equality.Semantic.DeepDerivative(httpRoute.Object["spec"], existHTTPRoute.Object["spec"]) ||
+		!equality.Semantic.DeepDerivative(httpRoute.GetAnnotations(), existHTTPRoute.GetAnnotations()) {
+		logger.Info("Updating HTTPRoute")
+		existHTTPRoute.Object["spec"] = httpRoute.Object["spec"]
+		existHTTPRoute.SetAnnotations(mergeStringMaps(existHTTPRoute.GetAnnotations(), httpRoute.GetAnnotations()))
+		return r.K8sClient.Update(ctx, existHTTPRoute)
+	}
+
+	return nil
+}
+
+// deleteIngresses deletes the generated Ingresses of the MLFlow server and of its path routed models
+func (r *MLFlowReconciler) deleteIngresses(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) error {
+	if err := r.deleteIngress(ctx, mlflowServerConfig, mlflowServerConfig.Name); err != nil {
+		return err
+	}
+	return r.deleteIngress(ctx, mlflowServerConfig, mlflow.ModelIngressName(mlflowServerConfig))
+}
+
+func (r *MLFlowReconciler) deleteIngress(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, name string) error {
+	ingress := &networkingv1.Ingress{}
+	err := r.K8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: mlflowServerConfig.Namespace}, ingress)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(ingress, mlflowServerConfig) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.K8sClient.Delete(ctx, ingress))
+}
+
+// deleteHTTPRoute deletes the generated HTTPRoute, ignoring clusters without the Gateway API
+func (r *MLFlowReconciler) deleteHTTPRoute(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) error {
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetAPIVersion(mlflow.HTTPRouteAPIVersion)
+	httpRoute.SetKind(mlflow.HTTPRouteKind)
+	err := r.K8sClient.Get(ctx, client.ObjectKeyFromObject(mlflowServerConfig), httpRoute)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(httpRoute, mlflowServerConfig) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.K8sClient.Delete(ctx, httpRoute))
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileIngress_NotControlled(t *testing.T) {
+	handWrittenIngress := func() client.Object {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml", Annotations: map[string]string{"owner": "team"}},
+		}
+	}
+	handWrittenHTTPRoute := func() client.Object {
+		httpRoute := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+		httpRoute.SetAPIVersion(mlflow.HTTPRouteAPIVersion)
+		httpRoute.SetKind(mlflow.HTTPRouteKind)
+		httpRoute.SetName("mlflow")
+		httpRoute.SetNamespace("ml")
+		httpRoute.SetAnnotations(map[string]string{"owner": "team"})
+		return httpRoute
+	}
+
+	tests := []struct {
+		existing    func() client.Object
+		ingressSpec *mlflowv1beta1.IngressSpec
+		name        string
+		wantErr     bool
+	}{
+		{
+			name:        "ingress",
+			existing:    handWrittenIngress,
+			ingressSpec: &mlflowv1beta1.IngressSpec{Host: "mlflow.example.com"},
+			wantErr:     true,
+		},
+		{
+			name:     "http route",
+			existing: handWrittenHTTPRoute,
+			ingressSpec: &mlflowv1beta1.IngressSpec{
+				Kind:       mlflowv1beta1.IngressKindHTTPRoute,
+				ParentRefs: []mlflowv1beta1.GatewayParentReference{{Name: "gateway"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "ingress kept when switching to http route",
+			existing: handWrittenIngress,
+			ingressSpec: &mlflowv1beta1.IngressSpec{
+				Kind:       mlflowv1beta1.IngressKindHTTPRoute,
+				ParentRefs: []mlflowv1beta1.GatewayParentReference{{Name: "gateway"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := newTestScheme(t)
+			mlflowServerConfig := newTestMLFlow()
+			mlflowServerConfig.Spec.Ingress = tt.ingressSpec
+			existing := tt.existing()
+
+			r := &MLFlowReconciler{
+				K8sClient:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build(),
+				Scheme:              scheme,
+				MlflowObjectManager: &mlflow.ObjectManager{Scheme: scheme},
+			}
+			err := r.ReconcileIngress(context.Background(), mlflowServerConfig)
+
+			current := existing.DeepCopyObject().(client.Object)
+			if getErr := r.K8sClient.Get(context.Background(), client.ObjectKeyFromObject(existing), current); getErr != nil {
+				t.Fatalf("Get() error = %v", getErr)
+			}
+			if len(current.GetOwnerReferences()) != 0 || current.GetAnnotations()["owner"] != "team" {
+				t.Errorf("hand written object was taken over, owners = %v, annotations = %v",
+					current.GetOwnerReferences(), current.GetAnnotations())
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReconcileIngress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow/service"
+	"github.com/Trendyol/mlflow-operator/internal/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cachedMlflowClient is an MLflow API client with the hash of the credentials it was created with
+type cachedMlflowClient struct {
+	client          *service.Client
+	credentialsHash string
+}
+
+// MlflowClientFor returns the MLflow API client of the MLFlow instance to the other reconcilers
+func (r *MLFlowReconciler) MlflowClientFor(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) (*service.Client, error) {
+	return r.mlflowClientFor(ctx, mlflowServerConfig)
+}
+
+// mlflowClientFor returns the MLflow API client of the MLFlow instance, creating it on first use
+// and replacing it when the server is exposed on another address or its credentials change
+func (r *MLFlowReconciler) mlflowClientFor(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) (*service.Client, error) {
+	key := client.ObjectKeyFromObject(mlflowServerConfig)
+	if r.Debug {
+		if err := service.ValidateDebugAccess(mlflowServerConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	options, err := r.httpClientOptions(ctx, mlflowServerConfig)
+	if err != nil {
+		return nil, err
+	}
+	credentialsHash := hashHTTPClientOptions(options)
+
+	r.mlflowClientsMu.Lock()
+	defer r.mlflowClientsMu.Unlock()
+
+	baseURL := service.NewClient(mlflowServerConfig, r.HTTPClient, r.Debug).BaseURL
+	if existingClient, ok := r.mlflowClients[key]; ok &&
+		existingClient.client.BaseURL == baseURL && existingClient.credentialsHash == credentialsHash {
+		return existingClient.client, nil
+	}
+
+	httpClient := r.HTTPClient
+	if options.Username != "" || options.Token != "" || len(options.CABundle) > 0 {
+		if httpClient, err = util.NewHTTPClientWithOptions(options); err != nil {
+			return nil, fmt.Errorf("unable to create MLflow API client: %w", err)
+		}
+	}
+
+	if r.mlflowClients == nil {
+		r.mlflowClients = map[types.NamespacedName]cachedMlflowClient{}
+	}
+	mlflowClient := service.NewClient(mlflowServerConfig, httpClient, r.Debug)
+	r.mlflowClients[key] = cachedMlflowClient{client: mlflowClient, credentialsHash: credentialsHash}
+	return mlflowClient, nil
+}
+
+// httpClientOptions reads the credentials and the CA bundle of the MLflow API client from the referenced Secrets
+func (r *MLFlowReconciler) httpClientOptions(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) (util.HTTPClientOptions, error) {
+	var options util.HTTPClientOptions
+	clientSpec := mlflowServerConfig.Spec.Client
+	if clientSpec == nil {
+		return options, nil
+	}
+
+	if clientSpec.BasicAuthSecret != nil {
+		usernameKey, passwordKey := clientBasicAuthSecretKeys(clientSpec.BasicAuthSecret)
+		secret := &corev1.Secret{}
+		if err := r.getSecret(ctx, clientSpec.BasicAuthSecret.Name, mlflowServerConfig.Namespace, secret); err != nil {
+			return options, fmt.Errorf("unable to get client basic auth secret %q: %w", clientSpec.BasicAuthSecret.Name, err)
+		}
+		options.Username = string(secret.Data[usernameKey])
+		options.Password = string(secret.Data[passwordKey])
+		if options.Username == "" {
+			return options, fmt.Errorf("client basic auth secret %q has no key %q", secret.Name, usernameKey)
+		}
+	}
+
+	if clientSpec.TokenSecret != nil {
+		token, err := r.getSecretValue(ctx, clientSpec.TokenSecret, mlflowServerConfig.Namespace)
+		if err != nil {
+			return options, err
+		}
+		options.Token = string(token)
+	}
+
+	if clientSpec.CABundleSecret != nil {
+		caBundle, err := r.getSecretValue(ctx, clientSpec.CABundleSecret, mlflowServerConfig.Namespace)
+		if err != nil {
+			return options, err
+		}
+		options.CABundle = caBundle
+	}
+
+	return options, nil
+}
+
+// clientBasicAuthSecretKeys returns the keys of the username and the password in the basic auth Secret of the client
+func clientBasicAuthSecretKeys(ref *mlflowv1beta1.CredentialsSecretReference) (usernameKey string, passwordKey string) {
+	usernameKey, passwordKey = ref.UsernameKey, ref.PasswordKey
+	if usernameKey == "" {
+		usernameKey = mlflowv1beta1.DefaultClientUsernameKey
+	}
+	if passwordKey == "" {
+		passwordKey = mlflowv1beta1.DefaultClientPasswordKey
+	}
+	return
+}
+
+func (r *MLFlowReconciler) getSecretValue(ctx context.Context, selector *corev1.SecretKeySelector, namespace string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.getSecret(ctx, selector.Name, namespace, secret); err != nil {
+		return nil, fmt.Errorf("unable to get secret %q: %w", selector.Name, err)
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no key %q", selector.Name, selector.Key)
+	}
+	return value, nil
+}
+
+func hashHTTPClientOptions(options util.HTTPClientOptions) string {
+	hash := sha256.New()
+	for _, value := range [][]byte{[]byte(options.Username), []byte(options.Password), []byte(options.Token), options.CABundle} {
+		hash.Write(value)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// removeMlflowClient forgets the MLflow API client of a deleted MLFlow instance
+func (r *MLFlowReconciler) removeMlflowClient(key types.NamespacedName) {
+	r.mlflowClientsMu.Lock()
+	defer r.mlflowClientsMu.Unlock()
+
+	delete(r.mlflowClients, key)
+}
@@ -14,12 +14,18 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/tools/reference"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -29,12 +35,20 @@ type MLFlowReconciler struct {
 	K8sClient           client.Client
 	Scheme              *runtime.Scheme
 	HTTPClient          util.HTTPClient
-	MlflowClient        *service.Client
 	MlflowObjectManager *mlflow.ObjectManager
+	Recorder            record.EventRecorder
+	mlflowClients       map[types.NamespacedName]cachedMlflowClient
+	modelScans          modelScanCache
+	mlflowClientsMu     sync.Mutex
 	Debug               bool
-	ModelSyncOnce       sync.Once
+	// UpdateModelDescriptions enables the legacy deployment messages written to the registered model descriptions
+	UpdateModelDescriptions bool
 }
 
+const (
+	defaultModelSyncPeriod = time.Minute
+)
+
 //+kubebuilder:rbac:groups=mlflow.trendyol.com,resources=mlflows,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mlflow.trendyol.com,resources=mlflows/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mlflow.trendyol.com,resources=mlflows/finalizers,verbs=update
@@ -42,6 +56,12 @@ type MLFlowReconciler struct {
 //+kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services/status,verbs=get
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -52,14 +72,36 @@ func (r *MLFlowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	mlflowServerConfig := mlflowv1beta1.MLFlow{}
 
 	if err := r.GetMlflowCRD(ctx, req.NamespacedName, &mlflowServerConfig); err != nil {
+		if errors.IsNotFound(err) {
+			r.removeMlflowClient(req.NamespacedName)
+			r.modelScans.remove(req.NamespacedName)
+			return reconcile.Result{}, nil
+		}
 		logger.Error(err, "unable to fetch mlflow server config")
 		return reconcile.Result{}, err
 	}
 
-	deployment, err := r.MlflowObjectManager.CreateMlflowDeploymentObject(req.Name, req.Namespace, &mlflowServerConfig)
+	if err := r.ValidateBackendStoreSecret(ctx, &mlflowServerConfig); err != nil {
+		logger.Error(err, "invalid backend store configuration")
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonBackendStoreSecretNotFound, err)
+	}
+
+	configMap, err := r.GetMLFlowConfigMap(ctx, &mlflowServerConfig)
 	if err != nil {
-		logger.Error(err, "unable to set ownership on deployment resource")
-		return reconcile.Result{}, err
+		logger.Error(err, "unable to fetch mlflow server configmap", "ConfigMap", mlflowServerConfig.Spec.ConfigMapName)
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonConfigMapNotFound, err)
+	}
+
+	modelSelector, err := mlflow.NewModelSelector(mlflowServerConfig.Spec.ModelSelection)
+	if err != nil {
+		logger.Error(err, "invalid model selection")
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonInvalidConfiguration, err)
+	}
+
+	deployment, err := r.MlflowObjectManager.CreateMlflowDeploymentObject(req.Name, req.Namespace, &mlflowServerConfig, configMap)
+	if err != nil {
+		logger.Error(err, "unable to create deployment object")
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonInvalidConfiguration, err)
 	}
 
 	if r.Debug {
@@ -69,41 +111,54 @@ func (r *MLFlowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	}
 
-	existingDeployment, err := r.CreateOrUpdateDeployment(ctx, deployment)
+	existingDeployment, err := r.CreateOrUpdateDeployment(ctx, &mlflowServerConfig, deployment)
 	if err != nil {
 		logger.Error(err, "unable to create Deployment for MlflowServerConfig")
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonDeploymentFailed, err)
 	}
 
-	r.UpdateStatus(ctx, &mlflowServerConfig, deployment, func(mlflowServerConfig *mlflowv1beta1.MLFlow, ref *corev1.ObjectReference) {
-		if mlflowServerConfig.Status.ActiveModels == nil {
-			mlflowServerConfig.Status.ActiveModels = make(map[string]corev1.ObjectReference)
-		}
-		mlflowServerConfig.Status.Active = *ref
-	})
+	ref, err := reference.GetReference(r.Scheme, existingDeployment)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	svc, err := r.MlflowObjectManager.CreateMlflowServiceObject(req.Name, req.Namespace, &mlflowServerConfig)
 	if err != nil {
 		logger.Error(err, "unable to create Client for MlflowServerConfig when creating service")
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonInvalidConfiguration, err)
 	}
 
-	_, err = r.CreateOrUpdateService(ctx, svc)
+	_, err = r.CreateOrUpdateService(ctx, &mlflowServerConfig, svc)
 	if err != nil {
 		logger.Error(err, "unable to create Client for MlflowServerConfig when pushing to k8s")
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonServiceFailed, err)
+	}
+
+	if err := r.ReconcileIngress(ctx, &mlflowServerConfig); err != nil {
+		logger.Error(err, "unable to reconcile ingress for MlflowServerConfig")
+		return reconcile.Result{}, r.degrade(ctx, &mlflowServerConfig, mlflowv1beta1.ReasonIngressFailed, err)
+	}
+
+	generation := mlflowServerConfig.Generation
+	deploymentIsNotReady := r.DeploymentIsNotReady(existingDeployment)
+	err = r.UpdateStatus(ctx, &mlflowServerConfig, func(status *mlflowv1beta1.MLFlowStatus) {
+		status.Active = *ref
+		status.ObservedGeneration = generation
+		status.ReadyReplicas = existingDeployment.Status.ReadyReplicas
+		setConditions(&status.Conditions, generation, deploymentConditions(deploymentIsNotReady)...)
+	})
+	if err != nil {
+		logger.Error(err, "unable to update status")
 		return reconcile.Result{}, err
 	}
 
-	if r.DeploymentIsNotReady(existingDeployment) {
+	if deploymentIsNotReady {
 		logger.Info("Waiting for Deployment to be ready", deployment.Namespace, deployment.Name)
 		return reconcile.Result{Requeue: true}, nil
 	}
 
 	logger.Info("Deployment is ready")
-	r.ModelSyncOnce.Do(func() {
-		r.InitializeMlflowClient(&mlflowServerConfig)
-		go r.StartMlFlowModelSync(deployment.Namespace, &mlflowServerConfig)
-	})
+	nextModelSync := r.syncModelsIfDue(ctx, &mlflowServerConfig, modelSelector)
 
 	if r.Debug {
 		if err := r.createTestModel(ctx, req, mlflowServerConfig); err != nil {
@@ -112,7 +167,7 @@ func (r *MLFlowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: nextModelSync}, nil
 }
 
 func (r *MLFlowReconciler) createTestModel(ctx context.Context, req ctrl.Request, mlflowServerConfig mlflowv1beta1.MLFlow) error {
@@ -131,14 +186,6 @@ func (r *MLFlowReconciler) GetMlflowCRD(ctx context.Context, namespace types.Nam
 	return r.K8sClient.Get(ctx, namespace, mlflowServerCfg)
 }
 
-func (r *MLFlowReconciler) InitializeMlflowClient(mlflowServerCfg *mlflowv1beta1.MLFlow) {
-	if r.MlflowClient != nil {
-		return
-	}
-
-	r.MlflowClient = service.NewClient(mlflowServerCfg, r.HTTPClient, r.Debug)
-}
-
 func (r *MLFlowReconciler) CreateMLFlowWineQualityJob(ctx context.Context, job *batchv1.Job) error {
 	if err := r.K8sClient.Create(ctx, job); err != nil {
 		if !errors.IsAlreadyExists(err) {
@@ -150,96 +197,428 @@ func (r *MLFlowReconciler) CreateMLFlowWineQualityJob(ctx context.Context, job *
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MLFlowReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.ModelSyncOnce = sync.Once{}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &mlflowv1beta1.MLFlow{}, configMapNameField, indexMLFlowByConfigMapName); err != nil {
+		return err
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&mlflowv1beta1.MLFlow{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
-		Complete(r)
+		Owns(&networkingv1.Ingress{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findMLFlowsForConfigMap))
+
+	// HTTPRoutes are only watched when the Gateway API is installed in the cluster
+	httpRouteGVK := schema.FromAPIVersionAndKind(mlflow.HTTPRouteAPIVersion, mlflow.HTTPRouteKind)
+	if _, err := mgr.GetRESTMapper().RESTMapping(httpRouteGVK.GroupKind(), httpRouteGVK.Version); err == nil {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(httpRouteGVK)
+		builder = builder.Owns(httpRoute)
+	}
+
+	return builder.Complete(r)
+}
+
+// syncModelsIfDue syncs the registered models when the sync period of the MLFlow instance has elapsed
+// and returns the duration until the next sync
+func (r *MLFlowReconciler) syncModelsIfDue(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, modelSelector *mlflow.ModelSelector) time.Duration {
+	period := modelSyncPeriod(mlflowServerConfig)
+	if r.modelScans.hasPendingVersions(client.ObjectKeyFromObject(mlflowServerConfig)) {
+		period = min(period, pendingModelVersionRequeuePeriod)
+	}
+
+	if lastSync := mlflowServerConfig.Status.LastModelSyncTime; lastSync != nil {
+		if untilNextSync := time.Until(lastSync.Add(period)); untilNextSync > 0 {
+			return untilNextSync
+		}
+	}
+
+	if r.MlFlowModelSync(ctx, mlflowServerConfig, modelSelector) {
+		return min(modelSyncPeriod(mlflowServerConfig), pendingModelVersionRequeuePeriod)
+	}
+	return modelSyncPeriod(mlflowServerConfig)
+}
+
+func modelSyncPeriod(mlflowServerConfig *mlflowv1beta1.MLFlow) time.Duration {
+	if mlflowServerConfig.Spec.ModelSyncPeriodInMinutes <= 0 {
+		return defaultModelSyncPeriod
+	}
+	return time.Minute * time.Duration(mlflowServerConfig.Spec.ModelSyncPeriodInMinutes)
 }
 
-func (r *MLFlowReconciler) MlFlowModelSync(namespace string, mlflowServerConfig *mlflowv1beta1.MLFlow) {
-	ctx := context.Background()
+// MlFlowModelSync deploys the READY versions of the selected models and reports whether
+// any of them is still pending registration
+func (r *MLFlowReconciler) MlFlowModelSync(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, modelSelector *mlflow.ModelSelector) bool {
 	logger := log.FromContext(ctx)
+	mlflowClient, err := r.mlflowClientFor(ctx, mlflowServerConfig)
+	if err != nil {
+		logger.Error(err, "unable to create MLflow API client")
+		r.updateModelSyncConditions(ctx, mlflowServerConfig, err, nil)
+		return false
+	}
 
-	models, getModelsErr := r.MlflowClient.GetLatestModels()
+	models, getModelsErr := mlflowClient.GetLatestModels(ctx, scanOptions(mlflowServerConfig))
 	if getModelsErr != nil {
 		logger.Error(getModelsErr, "unable to get latest models")
-		return
+		r.updateModelSyncConditions(ctx, mlflowServerConfig, getModelsErr, nil)
+		return false
 	}
 
-	for _, model := range models {
-		modelDetails, modelDetailsErr := r.MlflowClient.GetModelVersionDetail(model.Name, model.Version)
-		if modelDetailsErr != nil {
-			logger.Error(modelDetailsErr, "failed to get model details")
+	key := client.ObjectKeyFromObject(mlflowServerConfig)
+	r.modelScans.begin(mlflowServerConfig, time.Now())
+
+	var changedModels []mlflow.Model
+	desiredDeployments := make(map[string]struct{})
+	for _, model := range modelSelector.Select(models).ServedByAlias() {
+		deploymentName := model.GenerateDeploymentName(mlflowServerConfig.Name)
+		for _, servedDeploymentName := range servedModelDeployments(mlflowServerConfig, model) {
+			desiredDeployments[servedDeploymentName] = struct{}{}
+		}
+		if r.modelScans.isUnchanged(key, deploymentName, model) && r.refreshModelStatus(ctx, mlflowServerConfig, deploymentName) {
 			continue
 		}
+		changedModels = append(changedModels, model)
+	}
 
-		mlFlowOperatorTags := modelDetails.Tags.GetOperatorTags()
-		modelDeployment, modelDeploymentErr := r.MlflowObjectManager.CreateMlflowModelDeploymentObject(mlflow.ModelDeploymentObjectConfig{
-			Name:               strings.ToLower(model.Name),
-			Namespace:          namespace,
-			MlFlowServerConfig: mlflowServerConfig,
-			Model:              model,
-			CPURequest:         mlFlowOperatorTags.CPURequest,
-			CPULimit:           mlFlowOperatorTags.CPULimit,
-			MemoryRequest:      mlFlowOperatorTags.MemoryRequest,
-			MemoryLimit:        mlFlowOperatorTags.MemoryLimit,
-			MlFlowTrackingURI:  fmt.Sprintf("http://%s:5000", mlflowServerConfig.Name),
-			MlFlowModelImage:   mlflowServerConfig.Spec.ModelImage,
-		})
-		if modelDeploymentErr != nil {
-			logger.Error(modelDeploymentErr, "unable to create Deployment for Model when creating model deployment")
-		}
-
-		existingDeployment, err := r.CreateOrUpdateDeployment(ctx, modelDeployment)
-		if err != nil {
-			logger.Error(err, "unable to create Deployment for Model when pushing to k8s")
-			r.updateDescription(model.Name, "Your Mlflow deployment has been failed to deploy")
-			return
+	var failedModels []string
+	pendingVersions := false
+	details := getModelVersionDetails(ctx, mlflowClient, changedModels, modelSyncConcurrency(mlflowServerConfig))
+	for i, model := range changedModels {
+		deploymentName := model.GenerateDeploymentName(mlflowServerConfig.Name)
+		modelStatus := mlflowv1beta1.ModelStatus{
+			Name:           model.Name,
+			Version:        model.Version,
+			Alias:          model.Alias,
+			DeploymentName: deploymentName,
 		}
 
-		r.UpdateStatus(ctx, mlflowServerConfig, existingDeployment, func(mlflowServerConfig *mlflowv1beta1.MLFlow, ref *corev1.ObjectReference) {
-			if mlflowServerConfig.Status.ActiveModels == nil {
-				mlflowServerConfig.Status.ActiveModels = make(map[string]corev1.ObjectReference)
+		detail, err := details[i].detail, details[i].err
+		switch {
+		case err != nil:
+			logger.Error(err, "failed to get model details", "Model", model.Name, "Version", model.Version, "Alias", model.Alias)
+		case detail.IsPendingRegistration():
+			// the current deployment of the model, if any, keeps serving until the version is READY
+			logger.Info("model version is pending registration", "Model", model.Name, "Version", detail.Version, "Alias", model.Alias)
+			pendingVersions = true
+			continue
+		case detail.IsFailedRegistration():
+			model.Version = detail.Version
+			modelStatus.Version = detail.Version
+			err = fmt.Errorf("model version registration failed: %s", detail.StatusMessage)
+			r.reportModelVersionState(ctx, mlflowClient, model, detail.Tags, "", nil, err)
+		default:
+			err = r.deployModel(ctx, mlflowClient, mlflowServerConfig, model, detail, &modelStatus)
+		}
+		if modelStatus.ServingDeploymentName != "" {
+			desiredDeployments[modelStatus.ServingDeploymentName] = struct{}{}
+		}
+		if isModelPromotionInProgress(err) {
+			pendingVersions = true
+			r.updateModelStatus(ctx, mlflowServerConfig, modelStatus, nil)
+			continue
+		}
+		if err != nil {
+			if util.IsResourceDoesNotExist(err) {
+				logger.Info("model version was removed from the registry during the sync", "Model", model.Name, "Version", model.Version, "Alias", model.Alias)
+				continue
 			}
-			mlflowServerConfig.Status.ActiveModels[existingDeployment.Name] = *ref
-		})
-		r.updateDescription(model.Name, "Your Mlflow deployment has been deployed")
+			failedModels = append(failedModels, deploymentName)
+			r.updateModelStatus(ctx, mlflowServerConfig, modelStatus, err)
+			continue
+		}
+
+		r.updateModelStatus(ctx, mlflowServerConfig, modelStatus, nil)
+		r.modelScans.markDeployed(key, deploymentName, model)
+	}
+
+	r.deleteOrphanModelDeployments(ctx, mlflowServerConfig, desiredDeployments)
+	r.pruneModelStatuses(ctx, mlflowServerConfig)
+	if err := r.ReconcileIngress(ctx, mlflowServerConfig); err != nil {
+		logger.Error(err, "unable to update model routes")
 	}
+	r.updateModelSyncConditions(ctx, mlflowServerConfig, nil, failedModels)
+	r.modelScans.setPendingVersions(key, pendingVersions)
+	return pendingVersions
 }
 
-func (r *MLFlowReconciler) UpdateStatus(
+// deployModel creates or updates the Deployment and Service serving the model, recording their state in modelStatus
+// and the in-cluster URL of the model on the model version
+func (r *MLFlowReconciler) deployModel(
 	ctx context.Context,
+	mlflowClient *service.Client,
 	mlflowServerConfig *mlflowv1beta1.MLFlow,
-	obj runtime.Object,
-	callback func(mlflowServerConfig *mlflowv1beta1.MLFlow, ref *corev1.ObjectReference),
-) {
-	ref, err := reference.GetReference(r.Scheme, obj)
+	model mlflow.Model,
+	modelDetails *service.ModelVersionDetailResponse,
+	modelStatus *mlflowv1beta1.ModelStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	model.Version = modelDetails.Version
+	model.LastUpdatedTimestamp = modelDetails.LastUpdatedTimestamp
+	modelStatus.Version = modelDetails.Version
+	modelStatus.Stage = modelDetails.CurrentStage
+	modelStatus.Tags = modelDetails.Tags.GetOperatorTagValues()
+
+	modelDeploymentConfig := mlflow.ModelDeploymentObjectConfig{
+		Name:               strings.ToLower(model.Name),
+		Namespace:          mlflowServerConfig.Namespace,
+		MlFlowServerConfig: mlflowServerConfig,
+		Model:              model,
+		Port:               mlflowServerConfig.Spec.ModelPort,
+		MlFlowTrackingURI:  mlflow.TrackingURI(mlflowServerConfig),
+		MlFlowModelImage:   mlflowServerConfig.Spec.ModelImage,
+	}
+	rejections, err := r.applyOperatorTags(mlflowServerConfig, model, modelDetails.Tags, &modelDeploymentConfig)
+	if err != nil {
+		logger.Error(err, "rejected operator tags of model version", "Model", model.Name, "Version", model.Version)
+	} else if isModelPromoted(mlflowServerConfig, model) {
+		err = r.promoteModel(ctx, modelDeploymentConfig, modelStatus)
+		if isModelPromotionInProgress(err) {
+			return err
+		}
+	} else {
+		err = r.createOrUpdateModelObjects(ctx, modelDeploymentConfig, modelStatus)
+	}
+
+	r.reportModelVersionState(ctx, mlflowClient, model, modelDetails.Tags, modelStatus.Endpoint, rejections, err)
+	return err
+}
+
+// createOrUpdateModelObjects creates or updates the Deployment and Service serving the model, recording their state in modelStatus
+func (r *MLFlowReconciler) createOrUpdateModelObjects(
+	ctx context.Context,
+	modelDeploymentConfig mlflow.ModelDeploymentObjectConfig,
+	modelStatus *mlflowv1beta1.ModelStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	modelDeployment, err := r.MlflowObjectManager.CreateMlflowModelDeploymentObject(modelDeploymentConfig)
 	if err != nil {
+		logger.Error(err, "unable to create Deployment for Model when creating model deployment")
+		return err
+	}
+
+	existingDeployment, err := r.CreateOrUpdateDeployment(ctx, modelDeploymentConfig.MlFlowServerConfig, modelDeployment)
+	if err != nil {
+		logger.Error(err, "unable to create Deployment for Model when pushing to k8s")
+		return err
+	}
+
+	modelStatus.Replicas = *existingDeployment.Spec.Replicas
+	modelStatus.ReadyReplicas = existingDeployment.Status.ReadyReplicas
+
+	modelService, err := r.MlflowObjectManager.CreateMlflowModelServiceObject(modelDeploymentConfig)
+	if err != nil {
+		logger.Error(err, "unable to create Service for Model when creating model service")
+		return err
+	}
+
+	existingService, err := r.CreateOrUpdateService(ctx, modelDeploymentConfig.MlFlowServerConfig, modelService)
+	if err != nil {
+		logger.Error(err, "unable to create Service for Model when pushing to k8s")
+		return err
+	}
+
+	modelStatus.Endpoint = mlflow.ServiceURL(existingService)
+	return nil
+}
+
+// applyOperatorTags sets the serving configuration of the model version from its operator tags, reporting every
+// rejected tag as an event and returning the rejections. Resources exceeding their limits are returned as the error.
+func (r *MLFlowReconciler) applyOperatorTags(
+	mlflowServerConfig *mlflowv1beta1.MLFlow,
+	model mlflow.Model,
+	tags service.Tags,
+	modelDeploymentConfig *mlflow.ModelDeploymentObjectConfig,
+) ([]string, error) {
+	operatorTags, tagErrs := tags.ParseOperatorTags()
+	var rejections []string
+	for _, tagErr := range tagErrs {
+		r.recordWarning(mlflowServerConfig, "InvalidModelTag", "%s/%s: %s", model.Name, model.Version, tagErr.Error())
+		rejections = append(rejections, tagErr.Error())
+	}
+
+	image, err := r.MlflowObjectManager.ResolveModelImage(mlflowServerConfig, operatorTags.Image)
+	if err != nil {
+		r.recordWarning(mlflowServerConfig, "ModelImageRejected", "%s/%s: %s", model.Name, model.Version, err.Error())
+		rejections = append(rejections, err.Error())
+	}
+
+	modelDeploymentConfig.MlFlowModelImage = image
+	modelDeploymentConfig.Replicas = operatorTags.Replicas
+	modelDeploymentConfig.Workers = operatorTags.Workers
+	modelDeploymentConfig.CanaryWeight = operatorTags.CanaryWeight
+	modelDeploymentConfig.Timeout = operatorTags.Timeout
+	modelDeploymentConfig.EnvManager = operatorTags.EnvManager
+	modelDeploymentConfig.Runtime = operatorTags.Runtime
+	modelDeploymentConfig.Env = operatorTags.Env
+	modelDeploymentConfig.NodeSelector = operatorTags.NodeSelector
+	modelDeploymentConfig.Probes = operatorTags.Probes
+
+	resources, clamped, err := r.MlflowObjectManager.ResolveModelResources(mlflowServerConfig, operatorTags.ResourceRequirements())
+	for _, message := range clamped {
+		r.recordWarning(mlflowServerConfig, "ModelResourcesClamped", "%s/%s: %s", model.Name, model.Version, message)
+		rejections = append(rejections, message)
+	}
+	if err != nil {
+		r.recordWarning(mlflowServerConfig, "ModelResourcesRejected", "%s/%s: %s", model.Name, model.Version, err.Error())
+	}
+
+	modelDeploymentConfig.Resources = &resources
+	return rejections, err
+}
+
+// reportModelVersionState writes the serving state of the model to the tags of the model version, only setting the
+// changed tags. The error tag holds the rejections and the deployment error, it is cleared once they are resolved.
+func (r *MLFlowReconciler) reportModelVersionState(
+	ctx context.Context,
+	mlflowClient *service.Client,
+	model mlflow.Model,
+	tags service.Tags,
+	endpoint string,
+	rejections []string,
+	deployErr error,
+) {
+	logger := log.FromContext(ctx)
+	currentTags := tags.ToMap()
+
+	statusTagName := service.StatusTagName(model.Alias)
+	desiredTags := map[string]string{statusTagName: service.ModelVersionStatusDeployed}
+	if deployErr != nil {
+		desiredTags[statusTagName] = service.ModelVersionStatusFailed
+		rejections = append(rejections, deployErr.Error())
+	} else {
+		desiredTags[service.EndpointTagName(model.Alias)] = endpoint
+		if currentTags[statusTagName] != service.ModelVersionStatusDeployed {
+			desiredTags[service.DeployedAtTagName(model.Alias)] = time.Now().UTC().Format(time.RFC3339)
+		}
+	}
+	errorTagName := service.ErrorTagName(model.Alias)
+	if _, ok := currentTags[errorTagName]; ok || len(rejections) > 0 {
+		desiredTags[errorTagName] = strings.Join(rejections, "; ")
+	}
+
+	for key, value := range desiredTags {
+		if currentValue, ok := currentTags[key]; ok && currentValue == value {
+			continue
+		}
+		if err := mlflowClient.SetModelVersionTag(ctx, model.Name, model.Version, key, value); err != nil {
+			logger.Error(err, "unable to set tag of model version", "Model", model.Name, "Version", model.Version, "Tag", key)
+		}
+	}
+
+	if r.UpdateModelDescriptions {
+		message := "Your Mlflow deployment has been deployed"
+		if deployErr != nil {
+			message = "Your Mlflow deployment has been failed to deploy"
+		}
+		r.updateDescription(ctx, mlflowClient, model.Name, message)
+	}
+}
+
+func (r *MLFlowReconciler) recordWarning(mlflowServerConfig *mlflowv1beta1.MLFlow, reason string, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
 		return
 	}
+	r.Recorder.Eventf(mlflowServerConfig, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// getModelVersionDetail returns the details of the model version, resolving the version an alias currently points to
+func getModelVersionDetail(ctx context.Context, mlflowClient *service.Client, model mlflow.Model) (*service.ModelVersionDetailResponse, error) {
+	if model.Alias != "" {
+		return mlflowClient.GetModelVersionByAlias(ctx, model.Name, model.Alias)
+	}
+	return mlflowClient.GetModelVersionDetail(ctx, model.Name, model.Version)
+}
+
+func (r *MLFlowReconciler) updateModelStatus(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, modelStatus mlflowv1beta1.ModelStatus, deployErr error) {
+	logger := log.FromContext(ctx)
+
+	if deployErr != nil {
+		modelStatus.LastError = deployErr.Error()
+	}
 
-	callback(mlflowServerConfig, ref)
+	err := r.UpdateStatus(ctx, mlflowServerConfig, func(status *mlflowv1beta1.MLFlowStatus) {
+		setModelStatus(status, modelStatus)
+	})
+	if err != nil {
+		logger.Error(err, "unable to update status for model deployment", "Deployment", modelStatus.DeploymentName)
+	}
+}
 
-	if err = r.K8sClient.Status().Update(ctx, mlflowServerConfig); err != nil {
+// pruneModelStatuses removes the serving state of model deployments which no longer exist
+func (r *MLFlowReconciler) pruneModelStatuses(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) {
+	logger := log.FromContext(ctx)
+
+	var removedDeployments []string
+	for _, modelStatus := range mlflowServerConfig.Status.Models {
+		err := r.getMLFlowDeployment(ctx, modelStatus.DeploymentName, mlflowServerConfig.Namespace, &appsv1.Deployment{})
+		if errors.IsNotFound(err) {
+			removedDeployments = append(removedDeployments, modelStatus.DeploymentName)
+		}
+	}
+
+	if len(removedDeployments) == 0 {
 		return
 	}
+
+	err := r.UpdateStatus(ctx, mlflowServerConfig, func(status *mlflowv1beta1.MLFlowStatus) {
+		for _, deploymentName := range removedDeployments {
+			removeModelStatus(status, deploymentName)
+		}
+	})
+	if err != nil {
+		logger.Error(err, "unable to remove status of deleted model deployments")
+	}
 }
 
-func (r *MLFlowReconciler) StartMlFlowModelSync(namespace string, mlflowServerConfig *mlflowv1beta1.MLFlow) {
-	t := time.NewTicker(time.Minute * time.Duration(mlflowServerConfig.Spec.ModelSyncPeriodInMinutes))
-	r.MlFlowModelSync(namespace, mlflowServerConfig)
-	for range t.C {
-		r.MlFlowModelSync(namespace, mlflowServerConfig)
+func (r *MLFlowReconciler) updateModelSyncConditions(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, serverErr error, failedModels []string) {
+	logger := log.FromContext(ctx)
+
+	backendReachable := metav1.Condition{
+		Type:   mlflowv1beta1.ConditionTypeBackendReachable,
+		Status: metav1.ConditionTrue,
+		Reason: mlflowv1beta1.ReasonServerReachable,
+	}
+	modelSyncHealthy := metav1.Condition{
+		Type:   mlflowv1beta1.ConditionTypeModelSyncHealthy,
+		Status: metav1.ConditionTrue,
+		Reason: mlflowv1beta1.ReasonModelSyncSucceeded,
+	}
+
+	if serverErr != nil && util.IsTransient(serverErr) {
+		backendReachable.Status = metav1.ConditionFalse
+		backendReachable.Reason = mlflowv1beta1.ReasonServerUnreachable
+		backendReachable.Message = serverErr.Error()
+		modelSyncHealthy.Status = metav1.ConditionFalse
+		modelSyncHealthy.Reason = mlflowv1beta1.ReasonServerUnreachable
+		modelSyncHealthy.Message = serverErr.Error()
+	} else if serverErr != nil {
+		// the server answered, but rejected the request
+		modelSyncHealthy.Status = metav1.ConditionFalse
+		modelSyncHealthy.Reason = mlflowv1beta1.ReasonModelSyncFailed
+		modelSyncHealthy.Message = serverErr.Error()
+	} else if len(failedModels) > 0 {
+		modelSyncHealthy.Status = metav1.ConditionFalse
+		modelSyncHealthy.Reason = mlflowv1beta1.ReasonModelSyncFailed
+		modelSyncHealthy.Message = fmt.Sprintf("unable to deploy %s", strings.Join(failedModels, ", "))
+	}
+
+	generation := mlflowServerConfig.Generation
+	syncTime := metav1.Now()
+	err := r.UpdateStatus(ctx, mlflowServerConfig, func(status *mlflowv1beta1.MLFlowStatus) {
+		status.LastModelSyncTime = &syncTime
+		setConditions(&status.Conditions, generation, backendReachable, modelSyncHealthy)
+	})
+	if err != nil {
+		logger.Error(err, "unable to update model sync conditions")
 	}
 }
 
-func (r *MLFlowReconciler) updateDescription(name string, message string) {
+func (r *MLFlowReconciler) updateDescription(ctx context.Context, mlflowClient *service.Client, name string, message string) {
 	updateTime := time.Now().Format("15:04:05 2006-01-02")
 	msg := fmt.Sprintf("%s at %s", message, updateTime)
-	err := r.MlflowClient.UpdateDescription(name, msg)
+	err := mlflowClient.UpdateDescription(ctx, name, msg)
 	if err != nil {
 		return
 	}
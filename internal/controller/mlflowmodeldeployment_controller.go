@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow/service"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	mlflowRefNameField = ".spec.mlflowRef.name"
+	// modelDeploymentNameSuffix is appended to the name of the Deployment serving an MLflowModelDeployment,
+	// so that it does not collide with the Deployments of the MLFlow instance
+	modelDeploymentNameSuffix = "-model"
+)
+
+// MlflowClientProvider returns the MLflow API client of an MLFlow instance
+type MlflowClientProvider interface {
+	MlflowClientFor(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) (*service.Client, error)
+}
+
+// MLflowModelDeploymentReconciler reconciles a MLflowModelDeployment object
+type MLflowModelDeploymentReconciler struct {
+	K8sClient           client.Client
+	Scheme              *runtime.Scheme
+	MlflowObjectManager *mlflow.ObjectManager
+	// MlflowClients resolves the model version the alias of a registered model points to
+	MlflowClients MlflowClientProvider
+}
+
+//+kubebuilder:rbac:groups=mlflow.trendyol.com,resources=mlflowmodeldeployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mlflow.trendyol.com,resources=mlflowmodeldeployments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mlflow.trendyol.com,resources=mlflowmodeldeployments/finalizers,verbs=update
+
+// Reconcile serves the model version or alias declared by the MLflowModelDeployment
+func (r *MLflowModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	modelDeployment := &mlflowv1beta1.MLflowModelDeployment{}
+	if err := r.K8sClient.Get(ctx, req.NamespacedName, modelDeployment); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch mlflow model deployment")
+		return reconcile.Result{}, err
+	}
+
+	mlflowServerConfig := &mlflowv1beta1.MLFlow{}
+	mlflowKey := types.NamespacedName{Name: modelDeployment.Spec.MLFlowRef.Name, Namespace: modelDeployment.Namespace}
+	if err := r.K8sClient.Get(ctx, mlflowKey, mlflowServerConfig); err != nil {
+		logger.Error(err, "unable to fetch referenced mlflow server config", "MLFlow", mlflowKey.Name)
+		return reconcile.Result{}, r.degrade(ctx, modelDeployment, mlflowv1beta1.ReasonMLFlowNotFound, err)
+	}
+
+	version, err := r.resolveModelVersion(ctx, modelDeployment, mlflowServerConfig)
+	if err != nil {
+		logger.Error(err, "unable to resolve the model version of the alias", "Model", modelDeployment.Spec.ModelName, "Alias", modelDeployment.Spec.Alias)
+		return reconcile.Result{}, r.degrade(ctx, modelDeployment, mlflowv1beta1.ReasonModelAliasNotResolved, err)
+	}
+
+	deployment, err := r.MlflowObjectManager.CreateMlflowModelDeploymentObject(r.modelDeploymentObjectConfig(modelDeployment, mlflowServerConfig, version))
+	if err != nil {
+		logger.Error(err, "unable to create model deployment object")
+		return reconcile.Result{}, r.degrade(ctx, modelDeployment, mlflowv1beta1.ReasonInvalidConfiguration, err)
+	}
+
+	existingDeployment, err := createOrUpdateDeployment(ctx, r.K8sClient, modelDeployment, deployment)
+	if err != nil {
+		logger.Error(err, "unable to create Deployment for MLflowModelDeployment")
+		return reconcile.Result{}, r.degrade(ctx, modelDeployment, mlflowv1beta1.ReasonDeploymentFailed, err)
+	}
+
+	isNotReady := deploymentIsNotReady(existingDeployment)
+	err = r.updateStatus(ctx, modelDeployment, func(status *mlflowv1beta1.MLflowModelDeploymentStatus, generation int64) {
+		status.DeploymentName = existingDeployment.Name
+		status.Version = version
+		status.Replicas = *existingDeployment.Spec.Replicas
+		status.ReadyReplicas = existingDeployment.Status.ReadyReplicas
+		setConditions(&status.Conditions, generation, deploymentConditions(isNotReady)...)
+	})
+	if err != nil {
+		logger.Error(err, "unable to update status")
+		return reconcile.Result{}, err
+	}
+
+	if modelDeployment.Spec.Alias != "" {
+		// the registry is not watched, so the alias is resolved again to roll the deployment when it moves
+		return reconcile.Result{RequeueAfter: modelSyncPeriod(mlflowServerConfig)}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// modelDeploymentName returns the name of the Deployment serving the MLflowModelDeployment
+func modelDeploymentName(modelDeployment *mlflowv1beta1.MLflowModelDeployment) string {
+	return modelDeployment.Name + modelDeploymentNameSuffix
+}
+
+// resolveModelVersion returns the model version to serve, which is the version the alias currently points to
+// when the model is served by alias
+func (r *MLflowModelDeploymentReconciler) resolveModelVersion(
+	ctx context.Context,
+	modelDeployment *mlflowv1beta1.MLflowModelDeployment,
+	mlflowServerConfig *mlflowv1beta1.MLFlow,
+) (string, error) {
+	if modelDeployment.Spec.Alias == "" {
+		return modelDeployment.Spec.Version, nil
+	}
+	if r.MlflowClients == nil {
+		return "", fmt.Errorf("no MLflow API client to resolve the alias %q", modelDeployment.Spec.Alias)
+	}
+
+	mlflowClient, err := r.MlflowClients.MlflowClientFor(ctx, mlflowServerConfig)
+	if err != nil {
+		return "", err
+	}
+	modelVersion, err := mlflowClient.GetModelVersionByAlias(ctx, modelDeployment.Spec.ModelName, modelDeployment.Spec.Alias)
+	if err != nil {
+		return "", err
+	}
+	return modelVersion.Version, nil
+}
+
+// modelDeploymentObjectConfig builds the Deployment config of the MLflowModelDeployment, pinning the resolved version
+// in the pod template so that the pods roll when the alias moves
+func (r *MLflowModelDeploymentReconciler) modelDeploymentObjectConfig(
+	modelDeployment *mlflowv1beta1.MLflowModelDeployment,
+	mlflowServerConfig *mlflowv1beta1.MLFlow,
+	version string,
+) mlflow.ModelDeploymentObjectConfig {
+	image := modelDeployment.Spec.Image
+	if image == "" {
+		image = mlflowServerConfig.Spec.ModelImage
+	}
+
+	return mlflow.ModelDeploymentObjectConfig{
+		Name:               modelDeployment.Name,
+		DeploymentName:     modelDeploymentName(modelDeployment),
+		Namespace:          modelDeployment.Namespace,
+		MlFlowServerConfig: mlflowServerConfig,
+		Owner:              modelDeployment,
+		Model: mlflow.Model{
+			Name:    modelDeployment.Spec.ModelName,
+			Version: version,
+			Alias:   modelDeployment.Spec.Alias,
+		},
+		Replicas:          modelDeployment.Spec.Replicas,
+		Port:              mlflowServerConfig.Spec.ModelPort,
+		Resources:         &modelDeployment.Spec.Resources,
+		EnvManager:        modelDeployment.Spec.EnvManager,
+		Runtime:           modelDeployment.Spec.Runtime,
+		MlFlowTrackingURI: mlflow.TrackingURI(mlflowServerConfig),
+		MlFlowModelImage:  image,
+	}
+}
+
+func (r *MLflowModelDeploymentReconciler) updateStatus(
+	ctx context.Context,
+	modelDeployment *mlflowv1beta1.MLflowModelDeployment,
+	mutate func(status *mlflowv1beta1.MLflowModelDeploymentStatus, generation int64),
+) error {
+	key := client.ObjectKeyFromObject(modelDeployment)
+	generation := modelDeployment.Generation
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &mlflowv1beta1.MLflowModelDeployment{}
+		if err := r.K8sClient.Get(ctx, key, latest); err != nil {
+			return err
+		}
+
+		original := latest.Status.DeepCopy()
+		latest.Status.ObservedGeneration = generation
+		mutate(&latest.Status, generation)
+
+		if !equality.Semantic.DeepEqual(original, &latest.Status) {
+			if err := r.K8sClient.Status().Update(ctx, latest); err != nil {
+				return err
+			}
+		}
+
+		latest.DeepCopyInto(modelDeployment)
+		return nil
+	})
+}
+
+func (r *MLflowModelDeploymentReconciler) degrade(ctx context.Context, modelDeployment *mlflowv1beta1.MLflowModelDeployment, reason string, err error) error {
+	statusErr := r.updateStatus(ctx, modelDeployment, func(status *mlflowv1beta1.MLflowModelDeploymentStatus, generation int64) {
+		setConditions(&status.Conditions, generation, degradedConditions(reason, err)...)
+	})
+	if statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "unable to update status", "reason", reason)
+	}
+	return err
+}
+
+func indexModelDeploymentByMLFlowRef(obj client.Object) []string {
+	modelDeployment, ok := obj.(*mlflowv1beta1.MLflowModelDeployment)
+	if !ok || modelDeployment.Spec.MLFlowRef.Name == "" {
+		return nil
+	}
+	return []string{modelDeployment.Spec.MLFlowRef.Name}
+}
+
+// findModelDeploymentsForMLFlow maps an MLFlow event to the model deployments referencing it
+func (r *MLflowModelDeploymentReconciler) findModelDeploymentsForMLFlow(ctx context.Context, mlflowServerConfig client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	modelDeploymentList := &mlflowv1beta1.MLflowModelDeploymentList{}
+	err := r.K8sClient.List(ctx, modelDeploymentList,
+		client.InNamespace(mlflowServerConfig.GetNamespace()),
+		client.MatchingFields{mlflowRefNameField: mlflowServerConfig.GetName()},
+	)
+	if err != nil {
+		logger.Error(err, "unable to list MLflowModelDeployments for MLFlow", "MLFlow", mlflowServerConfig.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(modelDeploymentList.Items))
+	for _, item := range modelDeploymentList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MLflowModelDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &mlflowv1beta1.MLflowModelDeployment{}, mlflowRefNameField, indexModelDeploymentByMLFlowRef)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mlflowv1beta1.MLflowModelDeployment{}).
+		Owns(&appsv1.Deployment{}).
+		Watches(&mlflowv1beta1.MLFlow{}, handler.EnqueueRequestsFromMapFunc(r.findModelDeploymentsForMLFlow)).
+		Complete(r)
+}
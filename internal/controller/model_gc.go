@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// orphanedAtAnnotationKey holds the time a model deployment was first found serving a model version which is no longer served
+	orphanedAtAnnotationKey = "mlflow.trendyol.com/orphaned-at"
+)
+
+// deleteOrphanModelDeployments deletes the model deployments of the MLFlow instance which are not in the desired set
+// once their grace period has elapsed, only marking and logging them in dry run mode
+func (r *MLFlowReconciler) deleteOrphanModelDeployments(
+	ctx context.Context,
+	mlflowServerConfig *mlflowv1beta1.MLFlow,
+	desiredDeployments map[string]struct{},
+) {
+	logger := log.FromContext(ctx)
+
+	deploymentList := &appsv1.DeploymentList{}
+	err := r.K8sClient.List(ctx, deploymentList,
+		client.InNamespace(mlflowServerConfig.Namespace),
+		client.MatchingLabels{mlflow.MLFlowLabelKey: mlflow.ToLabelValue(mlflowServerConfig.Name)},
+	)
+	if err != nil {
+		logger.Error(err, "unable to list model deployments")
+		return
+	}
+
+	gracePeriod, dryRun := modelGarbageCollectionPolicy(mlflowServerConfig)
+	now := time.Now()
+
+	for i := range deploymentList.Items {
+		deployment := &deploymentList.Items[i]
+		if !isModelDeploymentOf(deployment, mlflowServerConfig) {
+			continue
+		}
+
+		if _, ok := desiredDeployments[deployment.Name]; ok {
+			if _, orphaned := deployment.Annotations[orphanedAtAnnotationKey]; orphaned {
+				r.setOrphanedAt(ctx, deployment, nil)
+			}
+			continue
+		}
+
+		orphanedAt, err := time.Parse(time.RFC3339, deployment.Annotations[orphanedAtAnnotationKey])
+		if err != nil {
+			orphanedAt = now
+			r.setOrphanedAt(ctx, deployment, &orphanedAt)
+		}
+
+		modelLogger := logger.WithValues(
+			"Deployment", deployment.Name,
+			"Model", deployment.Labels[mlflow.ModelNameLabelKey],
+			"Version", deployment.Labels[mlflow.ModelVersionLabelKey],
+		)
+		if now.Sub(orphanedAt) < gracePeriod {
+			modelLogger.Info("Orphan model deployment is waiting for its grace period", "OrphanedAt", orphanedAt)
+			continue
+		}
+
+		if dryRun {
+			modelLogger.Info("Orphan model deployment would be deleted, skipping in dry run mode")
+			continue
+		}
+
+		modelLogger.Info("Deleting orphan model deployment")
+		if err := r.K8sClient.Delete(ctx, deployment); client.IgnoreNotFound(err) != nil {
+			modelLogger.Error(err, "unable to delete orphan model deployment")
+			continue
+		}
+
+		r.deleteModelService(ctx, deployment)
+	}
+}
+
+// deleteModelService deletes the Service of the orphan model deployment, unless it was switched over to a promoted version
+func (r *MLFlowReconciler) deleteModelService(ctx context.Context, deployment *appsv1.Deployment) {
+	logger := log.FromContext(ctx).WithValues("Deployment", deployment.Name)
+	modelService := &corev1.Service{}
+	if err := getService(ctx, r.K8sClient, deployment.Name, deployment.Namespace, modelService); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "unable to get service of orphan model deployment")
+		}
+		return
+	}
+	if mlflow.ServingDeploymentName(modelService) != deployment.Name {
+		return
+	}
+
+	if err := r.K8sClient.Delete(ctx, modelService); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "unable to delete service of orphan model deployment")
+	}
+}
+
+// setOrphanedAt marks the model deployment as orphaned since the given time, or clears the mark when it is nil
+func (r *MLFlowReconciler) setOrphanedAt(ctx context.Context, deployment *appsv1.Deployment, orphanedAt *time.Time) {
+	patch := client.MergeFrom(deployment.DeepCopy())
+	if orphanedAt == nil {
+		delete(deployment.Annotations, orphanedAtAnnotationKey)
+	} else {
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[orphanedAtAnnotationKey] = orphanedAt.Format(time.RFC3339)
+	}
+
+	if err := r.K8sClient.Patch(ctx, deployment, patch); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update orphan mark of model deployment", "Deployment", deployment.Name)
+	}
+}
+
+// modelGarbageCollectionPolicy returns the grace period and the dry run mode of the garbage collection,
+// deleting orphan model deployments right away when the garbage collection is not configured
+func modelGarbageCollectionPolicy(mlflowServerConfig *mlflowv1beta1.MLFlow) (gracePeriod time.Duration, dryRun bool) {
+	gc := mlflowServerConfig.Spec.ModelGarbageCollection
+	if gc == nil {
+		return 0, false
+	}
+	return time.Minute * time.Duration(gc.GracePeriodInMinutes), gc.DryRun
+}
+
+// isModelDeploymentOf reports whether the deployment is a model deployment created by the model sync of the MLFlow instance
+func isModelDeploymentOf(deployment *appsv1.Deployment, mlflowServerConfig *mlflowv1beta1.MLFlow) bool {
+	return deployment.Name != mlflowServerConfig.Name && metav1.IsControlledBy(deployment, mlflowServerConfig)
+}
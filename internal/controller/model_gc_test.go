@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	if err := mlflowv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	return scheme
+}
+
+func newTestMLFlow() *mlflowv1beta1.MLFlow {
+	return &mlflowv1beta1.MLFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml", UID: "mlflow-uid"},
+	}
+}
+
+func newOrphanModelDeployment(t *testing.T, scheme *runtime.Scheme, mlflowServerConfig *mlflowv1beta1.MLFlow, orphanedAt time.Time) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mlflow-fraud-1",
+			Namespace:   mlflowServerConfig.Namespace,
+			Labels:      map[string]string{mlflow.MLFlowLabelKey: mlflow.ToLabelValue(mlflowServerConfig.Name)},
+			Annotations: map[string]string{orphanedAtAnnotationKey: orphanedAt.Format(time.RFC3339)},
+		},
+	}
+	if err := controllerutil.SetControllerReference(mlflowServerConfig, deployment, scheme); err != nil {
+		t.Fatalf("SetControllerReference() error = %v", err)
+	}
+	return deployment
+}
+
+func TestDeleteOrphanModelDeployments(t *testing.T) {
+	orphanedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		gc       *mlflowv1beta1.ModelGarbageCollectionSpec
+		name     string
+		expected bool
+	}{
+		{
+			name: "no garbage collection deletes orphan model deployments",
+		},
+		{
+			name:     "dry run keeps orphan model deployments",
+			gc:       &mlflowv1beta1.ModelGarbageCollectionSpec{DryRun: true},
+			expected: true,
+		},
+		{
+			name:     "grace period keeps orphan model deployments",
+			gc:       &mlflowv1beta1.ModelGarbageCollectionSpec{GracePeriodInMinutes: 120},
+			expected: true,
+		},
+		{
+			name: "elapsed grace period deletes orphan model deployments",
+			gc:   &mlflowv1beta1.ModelGarbageCollectionSpec{GracePeriodInMinutes: 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := newTestScheme(t)
+			mlflowServerConfig := newTestMLFlow()
+			mlflowServerConfig.Spec.ModelGarbageCollection = tt.gc
+			deployment := newOrphanModelDeployment(t, scheme, mlflowServerConfig, orphanedAt)
+
+			r := &MLFlowReconciler{
+				K8sClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build(),
+				Scheme:    scheme,
+			}
+			r.deleteOrphanModelDeployments(context.Background(), mlflowServerConfig, map[string]struct{}{})
+
+			err := r.K8sClient.Get(context.Background(), client.ObjectKeyFromObject(deployment), &appsv1.Deployment{})
+			if exists := err == nil; exists != tt.expected {
+				t.Errorf("deployment exists = %v, want %v (error = %v)", exists, tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultModelPromotionReadinessTimeout = 10 * time.Minute
+	crashLoopBackOffReason                = "CrashLoopBackOff"
+)
+
+// errModelPromotionInProgress is returned while the promoted model version is becoming ready, the old version keeps serving
+var errModelPromotionInProgress = errors.New("model version promotion is in progress")
+
+func isModelPromotionInProgress(err error) bool {
+	return errors.Is(err, errModelPromotionInProgress)
+}
+
+// isModelPromoted reports whether the model is promoted between version deployments instead of being updated in place
+func isModelPromoted(mlflowServerConfig *mlflowv1beta1.MLFlow, model mlflow.Model) bool {
+	return mlflowServerConfig.Spec.ModelPromotion != nil && model.Alias != ""
+}
+
+func modelPromotionReadinessTimeout(mlflowServerConfig *mlflowv1beta1.MLFlow) time.Duration {
+	modelPromotion := mlflowServerConfig.Spec.ModelPromotion
+	if modelPromotion == nil || modelPromotion.ReadinessTimeoutInMinutes <= 0 {
+		return defaultModelPromotionReadinessTimeout
+	}
+	return time.Minute * time.Duration(modelPromotion.ReadinessTimeoutInMinutes)
+}
+
+// servedModelDeployments returns the deployments serving the model, which are kept by the garbage collection
+func servedModelDeployments(mlflowServerConfig *mlflowv1beta1.MLFlow, model mlflow.Model) []string {
+	deploymentName := model.GenerateDeploymentName(mlflowServerConfig.Name)
+	if !isModelPromoted(mlflowServerConfig, model) {
+		return []string{deploymentName}
+	}
+
+	// the deployment updated in place keeps serving the alias until a version is promoted
+	servingDeploymentName := deploymentName
+	if modelStatus := findModelStatus(&mlflowServerConfig.Status, deploymentName); modelStatus != nil && modelStatus.ServingDeploymentName != "" {
+		servingDeploymentName = modelStatus.ServingDeploymentName
+	}
+	return []string{model.GenerateVersionDeploymentName(mlflowServerConfig.Name), servingDeploymentName}
+}
+
+// promoteModel deploys the model version next to the version served by the Service of its alias and switches the
+// Service over once the new version is ready, scaling down the old one. With a canary, the traffic is shifted to the
+// new version in steps before. The old version keeps serving while the new one is progressing, and takes the traffic
+// back when the new one crash loops or does not become ready within the readiness timeout.
+func (r *MLFlowReconciler) promoteModel(
+	ctx context.Context,
+	modelDeploymentConfig mlflow.ModelDeploymentObjectConfig,
+	modelStatus *mlflowv1beta1.ModelStatus,
+) error {
+	logger := log.FromContext(ctx)
+	mlflowServerConfig := modelDeploymentConfig.MlFlowServerConfig
+	model := modelDeploymentConfig.Model
+	serviceName := model.GenerateDeploymentName(mlflowServerConfig.Name)
+	versionDeploymentName := model.GenerateVersionDeploymentName(mlflowServerConfig.Name)
+
+	existingService := &corev1.Service{}
+	if err := getService(ctx, r.K8sClient, serviceName, mlflowServerConfig.Namespace, existingService); err == nil {
+		modelStatus.ServingDeploymentName = mlflow.ServingDeploymentName(existingService)
+		modelStatus.Endpoint = mlflow.ServiceURL(existingService)
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	if previousStatus := findModelStatus(&mlflowServerConfig.Status, serviceName); modelStatus.ServingDeploymentName == "" && previousStatus != nil {
+		// the Service selects every version during a canary
+		modelStatus.ServingDeploymentName = previousStatus.ServingDeploymentName
+	}
+	servingDeploymentName := modelStatus.ServingDeploymentName
+	promoted := servingDeploymentName == versionDeploymentName
+	r.setServingReplicas(ctx, mlflowServerConfig.Namespace, modelStatus)
+
+	promotion := modelPromotionStatus(mlflowServerConfig, serviceName, model.Version)
+	if promotion.Phase == mlflowv1beta1.ModelPromotionFailed && !promoted {
+		if promotion.LastUpdatedTimestamp == model.LastUpdatedTimestamp {
+			modelStatus.Promotion = promotion
+			return errors.New(promotion.Message)
+		}
+		// the model version was updated in the registry since its promotion failed, so it is retried
+		promotion = newModelPromotionStatus(model.Version)
+	}
+	promotion.DeploymentName = versionDeploymentName
+	canary := !promoted && r.isCanaryEnabled(ctx, modelDeploymentConfig, servingDeploymentName, serviceName)
+	weight := int32(100)
+	if canary {
+		weight = canaryWeight(modelDeploymentConfig, promotion)
+	}
+
+	versionConfig := modelDeploymentConfig
+	versionConfig.DeploymentName = versionDeploymentName
+	versionConfig.ServiceName = serviceName
+	// the deployment serves the version itself, so that it does not change when the alias moves
+	versionConfig.Model.Alias = ""
+	if canary && weight < 100 && !isTrafficSplitByRoute(mlflowServerConfig) {
+		canaryReplicas := mlflow.CanaryReplicas(modelStatus.Replicas, weight)
+		versionConfig.Replicas = &canaryReplicas
+	}
+	modelDeployment, err := r.MlflowObjectManager.CreateMlflowModelDeploymentObject(versionConfig)
+	if err != nil {
+		logger.Error(err, "unable to create Deployment for Model when creating model deployment")
+		return err
+	}
+	versionDeployment, err := r.CreateOrUpdateDeployment(ctx, mlflowServerConfig, modelDeployment)
+	if err != nil {
+		logger.Error(err, "unable to create Deployment for Model when pushing to k8s")
+		return err
+	}
+
+	if !promoted && !isDeploymentAvailable(versionDeployment) {
+		modelStatus.Promotion = promotion
+		since := promotion.StartedAt.Time
+		if promotion.Phase == mlflowv1beta1.ModelPromotionCanary {
+			since = promotion.LastStepTime.Time
+		}
+
+		promotionErr := r.modelPromotionFailure(ctx, versionDeployment, model.Version, since, modelPromotionReadinessTimeout(mlflowServerConfig))
+		if promotionErr == nil {
+			logger.Info("Waiting for the promoted model version to become ready", "Deployment", versionDeploymentName, "ServingDeployment", servingDeploymentName)
+			return errModelPromotionInProgress
+		}
+
+		if promotion.Phase == mlflowv1beta1.ModelPromotionCanary {
+			r.rollbackCanary(ctx, modelDeploymentConfig, servingDeploymentName, versionDeploymentName)
+		}
+		promotion.Phase = mlflowv1beta1.ModelPromotionFailed
+		promotion.Weight = 0
+		promotion.Message = promotionErr.Error()
+		promotion.LastUpdatedTimestamp = model.LastUpdatedTimestamp
+		r.scaleDeployment(ctx, versionDeployment, 0)
+		return promotionErr
+	}
+
+	if canary && weight < 100 {
+		// the last step sends all the traffic to the new version, which is promoted once it is available at full scale
+		canarySpec := mlflowServerConfig.Spec.ModelPromotion.Canary
+		if promotion.Phase == mlflowv1beta1.ModelPromotionCanary &&
+			time.Since(promotion.LastStepTime.Time) >= mlflow.CanaryStepInterval(canarySpec) &&
+			r.isCanaryWeightRouted(ctx, mlflowServerConfig, versionDeploymentName, weight) {
+			weight = mlflow.NextCanaryWeight(mlflow.CanaryWeights(canarySpec), weight)
+			if weight < 100 && !isTrafficSplitByRoute(mlflowServerConfig) {
+				r.scaleDeployment(ctx, versionDeployment, mlflow.CanaryReplicas(modelStatus.Replicas, weight))
+			}
+		}
+		if promotion.Phase != mlflowv1beta1.ModelPromotionCanary || promotion.Weight != weight {
+			logger.Info("Shifting traffic to the promoted model version", "Deployment", versionDeploymentName, "Weight", weight)
+			promotion.Phase = mlflowv1beta1.ModelPromotionCanary
+			promotion.Weight = weight
+			promotion.LastStepTime = metav1.Now()
+		}
+
+		modelStatus.Promotion = promotion
+		if err := r.splitModelTraffic(ctx, modelDeploymentConfig, versionDeploymentName); err != nil {
+			return err
+		}
+		return errModelPromotionInProgress
+	}
+
+	serviceConfig := modelDeploymentConfig
+	serviceConfig.ServingDeploymentName = versionDeploymentName
+	modelService, err := r.MlflowObjectManager.CreateMlflowModelServiceObject(serviceConfig)
+	if err != nil {
+		logger.Error(err, "unable to create Service for Model when creating model service")
+		return err
+	}
+	service, err := r.CreateOrUpdateService(ctx, mlflowServerConfig, modelService)
+	if err != nil {
+		logger.Error(err, "unable to create Service for Model when pushing to k8s")
+		return err
+	}
+
+	if servingDeploymentName != "" && !promoted {
+		logger.Info("Promoted model version", "Deployment", versionDeploymentName, "PreviousDeployment", servingDeploymentName)
+		r.deleteCanaryService(ctx, mlflowServerConfig.Namespace, versionDeploymentName)
+		previousDeployment := &appsv1.Deployment{}
+		if err := r.getMLFlowDeployment(ctx, servingDeploymentName, mlflowServerConfig.Namespace, previousDeployment); err == nil {
+			r.scaleDeployment(ctx, previousDeployment, 0)
+		} else if !apierrors.IsNotFound(err) {
+			logger.Error(err, "unable to get the previous model deployment", "Deployment", servingDeploymentName)
+		}
+	}
+
+	modelStatus.ServingDeploymentName = versionDeploymentName
+	modelStatus.Endpoint = mlflow.ServiceURL(service)
+	modelStatus.Promotion = nil
+	modelStatus.Replicas = *versionDeployment.Spec.Replicas
+	modelStatus.ReadyReplicas = versionDeployment.Status.ReadyReplicas
+	return nil
+}
+
+// isTrafficSplitByRoute reports whether the canary traffic is split by the weights of the HTTPRoute backends,
+// instead of the replicas of the versions behind the Service of the alias. The canary then only applies to the traffic
+// of the gateway, the in-cluster traffic sent to the Service of the alias keeps reaching the serving version.
+func isTrafficSplitByRoute(mlflowServerConfig *mlflowv1beta1.MLFlow) bool {
+	ingressSpec := mlflowServerConfig.Spec.Ingress
+	return ingressSpec != nil && ingressSpec.Kind == mlflowv1beta1.IngressKindHTTPRoute
+}
+
+// isCanaryEnabled reports whether the traffic of the alias is shifted to the new version in steps. The MLFlow instance
+// or the tag of the model version has to ask for a canary, and the pods of the serving version have to be shared behind
+// the Service of the alias when the traffic is split by replicas.
+func (r *MLFlowReconciler) isCanaryEnabled(
+	ctx context.Context,
+	modelDeploymentConfig mlflow.ModelDeploymentObjectConfig,
+	servingDeploymentName string,
+	serviceName string,
+) bool {
+	mlflowServerConfig := modelDeploymentConfig.MlFlowServerConfig
+	if servingDeploymentName == "" || mlflowServerConfig.Spec.ModelPromotion.Canary == nil && modelDeploymentConfig.CanaryWeight == nil {
+		return false
+	}
+	if isTrafficSplitByRoute(mlflowServerConfig) {
+		return true
+	}
+
+	servingDeployment := &appsv1.Deployment{}
+	if err := r.getMLFlowDeployment(ctx, servingDeploymentName, mlflowServerConfig.Namespace, servingDeployment); err != nil {
+		return false
+	}
+	return servingDeployment.Spec.Template.Labels[mlflow.ModelServiceLabelKey] == serviceName
+}
+
+// canaryWeight returns the traffic weight of the new model version, starting from the weight of its tag
+// or the first canary step
+func canaryWeight(modelDeploymentConfig mlflow.ModelDeploymentObjectConfig, promotion *mlflowv1beta1.ModelPromotionStatus) int32 {
+	if promotion.Phase == mlflowv1beta1.ModelPromotionCanary {
+		return promotion.Weight
+	}
+	if modelDeploymentConfig.CanaryWeight != nil {
+		return *modelDeploymentConfig.CanaryWeight
+	}
+	return mlflow.CanaryWeights(modelDeploymentConfig.MlFlowServerConfig.Spec.ModelPromotion.Canary)[0]
+}
+
+// isCanaryWeightRouted reports whether the traffic of the new model version is split by the current weight,
+// which is only the case once the HTTPRoute carrying the weight was applied when the traffic is split by route
+func (r *MLFlowReconciler) isCanaryWeightRouted(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, versionDeploymentName string, weight int32) bool {
+	if !isTrafficSplitByRoute(mlflowServerConfig) {
+		return true
+	}
+
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetAPIVersion(mlflow.HTTPRouteAPIVersion)
+	httpRoute.SetKind(mlflow.HTTPRouteKind)
+	if err := r.K8sClient.Get(ctx, client.ObjectKeyFromObject(mlflowServerConfig), httpRoute); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "unable to get the HTTPRoute of the canary", "Deployment", versionDeploymentName)
+		}
+		return false
+	}
+	routedWeight, ok := mlflow.HTTPRouteBackendWeight(httpRoute, versionDeploymentName)
+	return ok && routedWeight == weight
+}
+
+// splitModelTraffic sends the canary traffic to the new model version, through its own Service weighted by the HTTPRoute,
+// or by selecting the pods of every version with the Service of the alias
+func (r *MLFlowReconciler) splitModelTraffic(ctx context.Context, modelDeploymentConfig mlflow.ModelDeploymentObjectConfig, versionDeploymentName string) error {
+	serviceConfig := modelDeploymentConfig
+	if isTrafficSplitByRoute(modelDeploymentConfig.MlFlowServerConfig) {
+		serviceConfig.DeploymentName = versionDeploymentName
+		serviceConfig.Model.Alias = ""
+	}
+
+	modelService, err := r.MlflowObjectManager.CreateMlflowModelServiceObject(serviceConfig)
+	if err != nil {
+		return err
+	}
+	if !isTrafficSplitByRoute(modelDeploymentConfig.MlFlowServerConfig) {
+		modelService.Spec.Selector = mlflow.SharedModelServiceSelector(modelService.Name)
+	}
+	_, err = r.CreateOrUpdateService(ctx, modelDeploymentConfig.MlFlowServerConfig, modelService)
+	return err
+}
+
+// rollbackCanary sends the traffic of the alias back to the serving version
+func (r *MLFlowReconciler) rollbackCanary(
+	ctx context.Context,
+	modelDeploymentConfig mlflow.ModelDeploymentObjectConfig,
+	servingDeploymentName string,
+	versionDeploymentName string,
+) {
+	logger := log.FromContext(ctx)
+	logger.Info("Rolling back the canary of the model version", "Deployment", versionDeploymentName, "ServingDeployment", servingDeploymentName)
+	r.deleteCanaryService(ctx, modelDeploymentConfig.Namespace, versionDeploymentName)
+	if isTrafficSplitByRoute(modelDeploymentConfig.MlFlowServerConfig) {
+		return
+	}
+
+	serviceConfig := modelDeploymentConfig
+	serviceConfig.ServingDeploymentName = servingDeploymentName
+	modelService, err := r.MlflowObjectManager.CreateMlflowModelServiceObject(serviceConfig)
+	if err == nil {
+		_, err = r.CreateOrUpdateService(ctx, modelDeploymentConfig.MlFlowServerConfig, modelService)
+	}
+	if err != nil {
+		logger.Error(err, "unable to send the traffic back to the serving model deployment", "Deployment", servingDeploymentName)
+	}
+}
+
+func (r *MLFlowReconciler) deleteCanaryService(ctx context.Context, namespace string, versionDeploymentName string) {
+	canaryService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: versionDeploymentName, Namespace: namespace}}
+	if err := r.K8sClient.Delete(ctx, canaryService); client.IgnoreNotFound(err) != nil {
+		log.FromContext(ctx).Error(err, "unable to delete the canary service of the model version", "Service", versionDeploymentName)
+	}
+}
+
+// modelPromotionFailure returns why the promotion of the model version failed, or nil while it is progressing
+func (r *MLFlowReconciler) modelPromotionFailure(
+	ctx context.Context,
+	deployment *appsv1.Deployment,
+	version string,
+	since time.Time,
+	readinessTimeout time.Duration,
+) error {
+	podList := &corev1.PodList{}
+	err := r.K8sClient.List(ctx, podList,
+		client.InNamespace(deployment.Namespace),
+		client.MatchingLabels(deployment.Spec.Selector.MatchLabels),
+	)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to list pods of the promoted model version", "Deployment", deployment.Name)
+	}
+	for _, pod := range podList.Items {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Reason == crashLoopBackOffReason {
+				return fmt.Errorf("promotion of version %s failed: pod %s is crash looping", version, pod.Name)
+			}
+		}
+	}
+
+	if time.Since(since) > readinessTimeout {
+		return fmt.Errorf("promotion of version %s failed: not ready within %s", version, readinessTimeout)
+	}
+	return nil
+}
+
+// modelPromotionStatus returns the promotion of the model version recorded in the status, starting it when there is none
+func modelPromotionStatus(mlflowServerConfig *mlflowv1beta1.MLFlow, deploymentName string, version string) *mlflowv1beta1.ModelPromotionStatus {
+	modelStatus := findModelStatus(&mlflowServerConfig.Status, deploymentName)
+	if modelStatus != nil && modelStatus.Promotion != nil && modelStatus.Promotion.Version == version {
+		promotion := *modelStatus.Promotion
+		return &promotion
+	}
+	return newModelPromotionStatus(version)
+}
+
+func newModelPromotionStatus(version string) *mlflowv1beta1.ModelPromotionStatus {
+	return &mlflowv1beta1.ModelPromotionStatus{
+		StartedAt: metav1.Now(),
+		Version:   version,
+		Phase:     mlflowv1beta1.ModelPromotionProgressing,
+	}
+}
+
+// setServingReplicas records the replicas of the deployment still serving the model while a new version is promoted
+func (r *MLFlowReconciler) setServingReplicas(ctx context.Context, namespace string, modelStatus *mlflowv1beta1.ModelStatus) {
+	if modelStatus.ServingDeploymentName == "" {
+		return
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.getMLFlowDeployment(ctx, modelStatus.ServingDeploymentName, namespace, deployment); err != nil {
+		return
+	}
+	if deployment.Spec.Replicas != nil {
+		modelStatus.Replicas = *deployment.Spec.Replicas
+	}
+	modelStatus.ReadyReplicas = deployment.Status.ReadyReplicas
+}
+
+func (r *MLFlowReconciler) scaleDeployment(ctx context.Context, deployment *appsv1.Deployment, replicas int32) {
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == replicas {
+		return
+	}
+
+	patch := client.MergeFrom(deployment.DeepCopy())
+	deployment.Spec.Replicas = &replicas
+	if err := r.K8sClient.Patch(ctx, deployment, patch); err != nil {
+		log.FromContext(ctx).Error(err, "unable to scale model deployment", "Deployment", deployment.Name, "Replicas", replicas)
+	}
+}
+
+// isDeploymentAvailable reports whether every replica of the latest revision of the deployment is ready
+func isDeploymentAvailable(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.ReadyReplicas >= replicas
+}
@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testServiceName           = "mlflow-fraud-champion"
+	testVersionDeploymentName = "mlflow-fraud-champion-v2"
+)
+
+// newPromotionTestReconciler returns a reconciler serving the champion alias of the fraud model with its deployment
+// updated in place, before any version was promoted
+func newPromotionTestReconciler(t *testing.T, mlflowServerConfig *mlflowv1beta1.MLFlow, objects ...client.Object) *MLFlowReconciler {
+	scheme := newTestScheme(t)
+	om := &mlflow.ObjectManager{Scheme: scheme}
+	servingConfig := newPromotionTestConfig(mlflowServerConfig, 1)
+	servingConfig.Model.Version = "1"
+	modelService, err := om.CreateMlflowModelServiceObject(servingConfig)
+	if err != nil {
+		t.Fatalf("CreateMlflowModelServiceObject() error = %v", err)
+	}
+
+	return &MLFlowReconciler{
+		K8sClient:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, modelService)...).Build(),
+		Scheme:              scheme,
+		MlflowObjectManager: om,
+	}
+}
+
+func newPromotionTestConfig(mlflowServerConfig *mlflowv1beta1.MLFlow, lastUpdatedTimestamp int64) mlflow.ModelDeploymentObjectConfig {
+	return mlflow.ModelDeploymentObjectConfig{
+		Name:               "fraud",
+		Namespace:          mlflowServerConfig.Namespace,
+		MlFlowServerConfig: mlflowServerConfig,
+		Model:              mlflow.Model{Name: "fraud", Version: "2", Alias: "champion", LastUpdatedTimestamp: lastUpdatedTimestamp},
+	}
+}
+
+func newPromotionTestMLFlow(promotion *mlflowv1beta1.ModelPromotionStatus) *mlflowv1beta1.MLFlow {
+	mlflowServerConfig := newTestMLFlow()
+	mlflowServerConfig.Spec.ModelPromotion = &mlflowv1beta1.ModelPromotionSpec{}
+	mlflowServerConfig.Status.Models = []mlflowv1beta1.ModelStatus{
+		{Name: "fraud", Version: "1", Alias: "champion", DeploymentName: testServiceName, Promotion: promotion},
+	}
+	return mlflowServerConfig
+}
+
+func TestPromoteModel_FailedPromotion(t *testing.T) {
+	failedPromotion := &mlflowv1beta1.ModelPromotionStatus{
+		Version:              "2",
+		DeploymentName:       testVersionDeploymentName,
+		Phase:                mlflowv1beta1.ModelPromotionFailed,
+		Message:              "promotion of version 2 failed: not ready within 10m0s",
+		LastUpdatedTimestamp: 100,
+	}
+
+	tests := []struct {
+		name                 string
+		expectedPhase        mlflowv1beta1.ModelPromotionPhase
+		lastUpdatedTimestamp int64
+		retried              bool
+	}{
+		{
+			name:                 "unchanged model version is not promoted again",
+			lastUpdatedTimestamp: 100,
+			expectedPhase:        mlflowv1beta1.ModelPromotionFailed,
+		},
+		{
+			name:                 "updated model version is promoted again",
+			lastUpdatedTimestamp: 200,
+			expectedPhase:        mlflowv1beta1.ModelPromotionProgressing,
+			retried:              true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mlflowServerConfig := newPromotionTestMLFlow(failedPromotion)
+			r := newPromotionTestReconciler(t, mlflowServerConfig)
+
+			modelStatus := &mlflowv1beta1.ModelStatus{DeploymentName: testServiceName}
+			err := r.promoteModel(context.Background(), newPromotionTestConfig(mlflowServerConfig, tt.lastUpdatedTimestamp), modelStatus)
+			if tt.retried != isModelPromotionInProgress(err) {
+				t.Errorf("promoteModel() error = %v, want in progress %v", err, tt.retried)
+			}
+			if !tt.retried && (err == nil || err.Error() != failedPromotion.Message) {
+				t.Errorf("promoteModel() error = %v, want %s", err, failedPromotion.Message)
+			}
+			if modelStatus.Promotion == nil || modelStatus.Promotion.Phase != tt.expectedPhase {
+				t.Errorf("promoteModel() promotion = %v, want phase %s", modelStatus.Promotion, tt.expectedPhase)
+			}
+
+			err = r.K8sClient.Get(context.Background(), types.NamespacedName{Name: testVersionDeploymentName, Namespace: "ml"}, &appsv1.Deployment{})
+			if created := !apierrors.IsNotFound(err); created != tt.retried {
+				t.Errorf("version deployment created = %v, want %v", created, tt.retried)
+			}
+		})
+	}
+}
+
+func TestPromoteModel_CrashLoopingVersion(t *testing.T) {
+	mlflowServerConfig := newPromotionTestMLFlow(nil)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: testVersionDeploymentName + "-abc", Namespace: "ml", Labels: map[string]string{"app": testVersionDeploymentName}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: crashLoopBackOffReason}},
+		}}},
+	}
+	r := newPromotionTestReconciler(t, mlflowServerConfig, pod)
+
+	modelStatus := &mlflowv1beta1.ModelStatus{DeploymentName: testServiceName}
+	err := r.promoteModel(context.Background(), newPromotionTestConfig(mlflowServerConfig, 100), modelStatus)
+	if err == nil || isModelPromotionInProgress(err) {
+		t.Fatalf("promoteModel() error = %v, want the promotion to fail", err)
+	}
+	promotion := modelStatus.Promotion
+	if promotion == nil || promotion.Phase != mlflowv1beta1.ModelPromotionFailed ||
+		promotion.Message != err.Error() || promotion.LastUpdatedTimestamp != 100 {
+		t.Errorf("promoteModel() promotion = %v, want a failed promotion of timestamp 100", promotion)
+	}
+
+	versionDeployment := &appsv1.Deployment{}
+	if err := r.K8sClient.Get(context.Background(), types.NamespacedName{Name: testVersionDeploymentName, Namespace: "ml"}, versionDeployment); err != nil {
+		t.Fatalf("Get() version deployment error = %v", err)
+	}
+	if *versionDeployment.Spec.Replicas != 0 {
+		t.Errorf("version deployment replicas = %d, want 0", *versionDeployment.Spec.Replicas)
+	}
+}
+
+// newCanaryTestReconciler returns a reconciler serving the champion alias of the fraud model with two replicas shared
+// behind the Service of the alias, and the available deployment of the promoted version 2
+func newCanaryTestReconciler(t *testing.T, mlflowServerConfig *mlflowv1beta1.MLFlow) *MLFlowReconciler {
+	scheme := newTestScheme(t)
+	om := &mlflow.ObjectManager{Scheme: scheme}
+
+	servingReplicas := int32(2)
+	servingConfig := newPromotionTestConfig(mlflowServerConfig, 100)
+	servingConfig.Model.Version = "1"
+	servingConfig.ServiceName = testServiceName
+	servingConfig.Replicas = &servingReplicas
+	servingDeployment, err := om.CreateMlflowModelDeploymentObject(servingConfig)
+	if err != nil {
+		t.Fatalf("CreateMlflowModelDeploymentObject() error = %v", err)
+	}
+	modelService, err := om.CreateMlflowModelServiceObject(servingConfig)
+	if err != nil {
+		t.Fatalf("CreateMlflowModelServiceObject() error = %v", err)
+	}
+
+	versionConfig := newPromotionTestConfig(mlflowServerConfig, 100)
+	versionConfig.DeploymentName = testVersionDeploymentName
+	versionConfig.ServiceName = testServiceName
+	versionConfig.Model.Alias = ""
+	versionDeployment, err := om.CreateMlflowModelDeploymentObject(versionConfig)
+	if err != nil {
+		t.Fatalf("CreateMlflowModelDeploymentObject() error = %v", err)
+	}
+	versionDeployment.Status = appsv1.DeploymentStatus{UpdatedReplicas: servingReplicas, ReadyReplicas: servingReplicas}
+
+	return &MLFlowReconciler{
+		K8sClient:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(servingDeployment, modelService, versionDeployment).Build(),
+		Scheme:              scheme,
+		MlflowObjectManager: om,
+	}
+}
+
+func TestPromoteModel_CanarySteps(t *testing.T) {
+	tagWeight := int32(30)
+	canaryPromotion := func(weight int32, lastStep time.Duration) *mlflowv1beta1.ModelPromotionStatus {
+		return &mlflowv1beta1.ModelPromotionStatus{
+			StartedAt:      metav1.NewTime(time.Now().Add(-time.Hour)),
+			LastStepTime:   metav1.NewTime(time.Now().Add(-lastStep)),
+			Version:        "2",
+			DeploymentName: testVersionDeploymentName,
+			Phase:          mlflowv1beta1.ModelPromotionCanary,
+			Weight:         weight,
+		}
+	}
+
+	tests := []struct {
+		promotion      *mlflowv1beta1.ModelPromotionStatus
+		tagWeight      *int32
+		name           string
+		expectedWeight int32
+		promoted       bool
+	}{
+		{name: "first step", expectedWeight: 10},
+		{name: "tag weight is the first step", tagWeight: &tagWeight, expectedWeight: 30},
+		{name: "step interval has not elapsed", promotion: canaryPromotion(10, time.Minute), expectedWeight: 10},
+		{name: "next step", promotion: canaryPromotion(10, 10*time.Minute), expectedWeight: 50},
+		{name: "steps after the tag weight", promotion: canaryPromotion(30, 10*time.Minute), tagWeight: &tagWeight, expectedWeight: 50},
+		{name: "last step", promotion: canaryPromotion(50, 10*time.Minute), expectedWeight: 100},
+		{name: "promoted after the last step", promotion: canaryPromotion(100, time.Minute), promoted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mlflowServerConfig := newPromotionTestMLFlow(tt.promotion)
+			mlflowServerConfig.Spec.ModelPromotion.Canary = &mlflowv1beta1.CanarySpec{Weights: []int32{10, 50}, StepIntervalInMinutes: 5}
+			r := newCanaryTestReconciler(t, mlflowServerConfig)
+
+			modelDeploymentConfig := newPromotionTestConfig(mlflowServerConfig, 100)
+			modelDeploymentConfig.CanaryWeight = tt.tagWeight
+			modelStatus := &mlflowv1beta1.ModelStatus{DeploymentName: testServiceName}
+			err := r.promoteModel(context.Background(), modelDeploymentConfig, modelStatus)
+
+			modelService := &corev1.Service{}
+			if getErr := r.K8sClient.Get(context.Background(), types.NamespacedName{Name: testServiceName, Namespace: "ml"}, modelService); getErr != nil {
+				t.Fatalf("Get() service error = %v", getErr)
+			}
+
+			if tt.promoted {
+				if err != nil || modelStatus.Promotion != nil || modelStatus.ServingDeploymentName != testVersionDeploymentName {
+					t.Errorf("promoteModel() error = %v, promotion = %v, serving = %s, want promoted", err, modelStatus.Promotion, modelStatus.ServingDeploymentName)
+				}
+				if got := mlflow.ServingDeploymentName(modelService); got != testVersionDeploymentName {
+					t.Errorf("service selects %s, want %s", got, testVersionDeploymentName)
+				}
+				return
+			}
+
+			if !isModelPromotionInProgress(err) {
+				t.Errorf("promoteModel() error = %v, want in progress", err)
+			}
+			promotion := modelStatus.Promotion
+			if promotion == nil || promotion.Phase != mlflowv1beta1.ModelPromotionCanary || promotion.Weight != tt.expectedWeight {
+				t.Errorf("promoteModel() promotion = %v, want canary weight %d", promotion, tt.expectedWeight)
+			}
+			if !reflect.DeepEqual(modelService.Spec.Selector, mlflow.SharedModelServiceSelector(testServiceName)) {
+				t.Errorf("service selector = %v, want the pods of every version", modelService.Spec.Selector)
+			}
+		})
+	}
+}
+
+func TestPromoteModel_CanaryStepWaitsForRoute(t *testing.T) {
+	previousWeight, currentWeight := int32(5), int32(10)
+	tests := []struct {
+		routedWeight   *int32
+		name           string
+		expectedWeight int32
+	}{
+		{name: "no route", expectedWeight: 10},
+		{name: "route carries the previous weight", routedWeight: &previousWeight, expectedWeight: 10},
+		{name: "route carries the current weight", routedWeight: &currentWeight, expectedWeight: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mlflowServerConfig := newPromotionTestMLFlow(&mlflowv1beta1.ModelPromotionStatus{
+				StartedAt:      metav1.NewTime(time.Now().Add(-time.Hour)),
+				LastStepTime:   metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				Version:        "2",
+				DeploymentName: testVersionDeploymentName,
+				Phase:          mlflowv1beta1.ModelPromotionCanary,
+				Weight:         10,
+			})
+			mlflowServerConfig.Spec.ModelPromotion.Canary = &mlflowv1beta1.CanarySpec{Weights: []int32{10, 50}, StepIntervalInMinutes: 5}
+			mlflowServerConfig.Spec.Ingress = &mlflowv1beta1.IngressSpec{
+				Kind:       mlflowv1beta1.IngressKindHTTPRoute,
+				ParentRefs: []mlflowv1beta1.GatewayParentReference{{Name: "gateway"}},
+			}
+			r := newCanaryTestReconciler(t, mlflowServerConfig)
+			if tt.routedWeight != nil {
+				httpRoute, err := r.MlflowObjectManager.CreateMlflowHTTPRouteObject(mlflowServerConfig, []mlflow.ModelRoute{{
+					Path:              "/models/fraud/champion",
+					ServiceName:       testServiceName,
+					CanaryServiceName: testVersionDeploymentName,
+					CanaryWeight:      *tt.routedWeight,
+				}})
+				if err != nil {
+					t.Fatalf("CreateMlflowHTTPRouteObject() error = %v", err)
+				}
+				if err := r.K8sClient.Create(context.Background(), httpRoute); err != nil {
+					t.Fatalf("Create() HTTPRoute error = %v", err)
+				}
+			}
+
+			modelStatus := &mlflowv1beta1.ModelStatus{DeploymentName: testServiceName}
+			err := r.promoteModel(context.Background(), newPromotionTestConfig(mlflowServerConfig, 100), modelStatus)
+			if !isModelPromotionInProgress(err) {
+				t.Errorf("promoteModel() error = %v, want in progress", err)
+			}
+			if promotion := modelStatus.Promotion; promotion == nil || promotion.Weight != tt.expectedWeight {
+				t.Errorf("promoteModel() promotion = %v, want canary weight %d", promotion, tt.expectedWeight)
+			}
+		})
+	}
+}
+
+func TestRollbackCanary(t *testing.T) {
+	mlflowServerConfig := newPromotionTestMLFlow(nil)
+	r := newCanaryTestReconciler(t, mlflowServerConfig)
+	modelDeploymentConfig := newPromotionTestConfig(mlflowServerConfig, 100)
+	if err := r.splitModelTraffic(context.Background(), modelDeploymentConfig, testVersionDeploymentName); err != nil {
+		t.Fatalf("splitModelTraffic() error = %v", err)
+	}
+
+	r.rollbackCanary(context.Background(), modelDeploymentConfig, testServiceName, testVersionDeploymentName)
+
+	modelService := &corev1.Service{}
+	if err := r.K8sClient.Get(context.Background(), types.NamespacedName{Name: testServiceName, Namespace: "ml"}, modelService); err != nil {
+		t.Fatalf("Get() service error = %v", err)
+	}
+	if got := mlflow.ServingDeploymentName(modelService); got != testServiceName {
+		t.Errorf("service selects %s, want %s", got, testServiceName)
+	}
+}
+
+func TestModelPromotionFailure(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: testVersionDeploymentName, Namespace: "ml"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": testVersionDeploymentName}}},
+	}
+
+	tests := []struct {
+		name    string
+		since   time.Duration
+		wantErr bool
+	}{
+		{name: "progressing", since: time.Minute},
+		{name: "not ready within the readiness timeout", since: 20 * time.Minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &MLFlowReconciler{K8sClient: fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()}
+			err := r.modelPromotionFailure(context.Background(), deployment, "2", time.Now().Add(-tt.since), 10*time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("modelPromotionFailure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
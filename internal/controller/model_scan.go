@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow/service"
+	"github.com/Trendyol/mlflow-operator/internal/util"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultModelFullResyncPeriod = time.Hour
+	defaultModelSyncConcurrency  = 4
+	// pendingModelVersionRequeuePeriod is the sync period while model versions are pending registration
+	pendingModelVersionRequeuePeriod = 15 * time.Second
+)
+
+// modelScanCache remembers the last_updated_timestamp of the model versions deployed for every MLFlow instance,
+// so that the syncs only deploy the model versions updated in the registry. It is kept in memory, which invalidates it
+// on operator restart, and is reset on every full resync or when the MLFlow instance changes.
+type modelScanCache struct {
+	instances map[types.NamespacedName]*modelScanState
+	mu        sync.Mutex
+}
+
+type modelScanState struct {
+	lastFullResync time.Time
+	// deployed holds the last_updated_timestamp of the deployed model versions keyed by deployment name and version
+	deployed   map[string]int64
+	generation int64
+	// pendingVersions is set when the last sync found model versions pending registration or being promoted
+	pendingVersions bool
+}
+
+// begin starts a sync of the MLFlow instance, resetting its cache when a full resync is due
+func (c *modelScanCache) begin(mlflowServerConfig *mlflowv1beta1.MLFlow, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := client.ObjectKeyFromObject(mlflowServerConfig)
+	state, ok := c.instances[key]
+	if ok && state.generation == mlflowServerConfig.Generation &&
+		now.Sub(state.lastFullResync) < modelFullResyncPeriod(mlflowServerConfig) {
+		return
+	}
+
+	if c.instances == nil {
+		c.instances = map[types.NamespacedName]*modelScanState{}
+	}
+	c.instances[key] = &modelScanState{
+		lastFullResync: now,
+		deployed:       map[string]int64{},
+		generation:     mlflowServerConfig.Generation,
+	}
+}
+
+// isUnchanged reports whether the model version was deployed and has not been updated in the registry since
+func (c *modelScanCache) isUnchanged(key types.NamespacedName, deploymentName string, model mlflow.Model) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.instances[key]
+	if !ok || model.LastUpdatedTimestamp == 0 {
+		return false
+	}
+	lastUpdatedTimestamp, ok := state.deployed[deploymentName+"/"+model.Version]
+	return ok && lastUpdatedTimestamp == model.LastUpdatedTimestamp
+}
+
+func (c *modelScanCache) markDeployed(key types.NamespacedName, deploymentName string, model mlflow.Model) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state, ok := c.instances[key]; ok {
+		state.deployed[deploymentName+"/"+model.Version] = model.LastUpdatedTimestamp
+	}
+}
+
+func (c *modelScanCache) setPendingVersions(key types.NamespacedName, pending bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state, ok := c.instances[key]; ok {
+		state.pendingVersions = pending
+	}
+}
+
+// hasPendingVersions reports whether the last sync of the MLFlow instance found model versions pending registration
+func (c *modelScanCache) hasPendingVersions(key types.NamespacedName) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.instances[key]
+	return ok && state.pendingVersions
+}
+
+func (c *modelScanCache) remove(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.instances, key)
+}
+
+func modelFullResyncPeriod(mlflowServerConfig *mlflowv1beta1.MLFlow) time.Duration {
+	modelSync := mlflowServerConfig.Spec.ModelSync
+	if modelSync == nil || modelSync.FullResyncPeriodInMinutes <= 0 {
+		return defaultModelFullResyncPeriod
+	}
+	return time.Minute * time.Duration(modelSync.FullResyncPeriodInMinutes)
+}
+
+func modelSyncConcurrency(mlflowServerConfig *mlflowv1beta1.MLFlow) int {
+	modelSync := mlflowServerConfig.Spec.ModelSync
+	if modelSync == nil || modelSync.Concurrency <= 0 {
+		return defaultModelSyncConcurrency
+	}
+	return int(modelSync.Concurrency)
+}
+
+// scanOptions filters the model versions on the server by the selected tag value. A tag selected without
+// a value matches any value, which the server cannot filter on, so it is left to the model selector.
+func scanOptions(mlflowServerConfig *mlflowv1beta1.MLFlow) service.ScanOptions {
+	options := service.ScanOptions{Concurrency: modelSyncConcurrency(mlflowServerConfig)}
+	if modelSelection := mlflowServerConfig.Spec.ModelSelection; modelSelection != nil &&
+		modelSelection.Tag != nil && modelSelection.Tag.Value != "" {
+		options.Tags = map[string]string{modelSelection.Tag.Key: modelSelection.Tag.Value}
+	}
+	return options
+}
+
+// modelVersionDetail is the result of fetching the details of a model version
+type modelVersionDetail struct {
+	err    error
+	detail *service.ModelVersionDetailResponse
+}
+
+// getModelVersionDetails fetches the details of the model versions concurrently
+func getModelVersionDetails(
+	ctx context.Context,
+	mlflowClient *service.Client,
+	models []mlflow.Model,
+	concurrency int,
+) []modelVersionDetail {
+	details := make([]modelVersionDetail, len(models))
+	util.ForEachConcurrently(len(models), concurrency, func(i int) {
+		details[i].detail, details[i].err = getModelVersionDetail(ctx, mlflowClient, models[i])
+	})
+	return details
+}
+
+// refreshModelStatus updates the replicas of an unchanged model deployment in the status,
+// reporting false when the deployment or its status is missing so that the model is deployed again
+func (r *MLFlowReconciler) refreshModelStatus(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, deploymentName string) bool {
+	var modelStatus *mlflowv1beta1.ModelStatus
+	for i := range mlflowServerConfig.Status.Models {
+		if mlflowServerConfig.Status.Models[i].DeploymentName == deploymentName {
+			modelStatus = &mlflowServerConfig.Status.Models[i]
+		}
+	}
+	if modelStatus == nil || modelStatus.LastError != "" || modelStatus.Promotion != nil {
+		return false
+	}
+
+	servingDeploymentName := deploymentName
+	if modelStatus.ServingDeploymentName != "" {
+		servingDeploymentName = modelStatus.ServingDeploymentName
+	}
+	deployment := &appsv1.Deployment{}
+	if err := r.getMLFlowDeployment(ctx, servingDeploymentName, mlflowServerConfig.Namespace, deployment); err != nil {
+		return false
+	}
+
+	if deployment.Spec.Replicas != nil && (modelStatus.Replicas != *deployment.Spec.Replicas ||
+		modelStatus.ReadyReplicas != deployment.Status.ReadyReplicas) {
+		refreshed := *modelStatus
+		refreshed.Replicas = *deployment.Spec.Replicas
+		refreshed.ReadyReplicas = deployment.Status.ReadyReplicas
+		r.updateModelStatus(ctx, mlflowServerConfig, refreshed, nil)
+	}
+	return true
+}
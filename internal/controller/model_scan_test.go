@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+func TestScanOptions(t *testing.T) {
+	tests := []struct {
+		modelSelection *mlflowv1beta1.ModelSelectionSpec
+		expectedTags   map[string]string
+		name           string
+	}{
+		{name: "no model selection"},
+		{name: "no tag", modelSelection: &mlflowv1beta1.ModelSelectionSpec{}},
+		{
+			name:           "tag with value",
+			modelSelection: &mlflowv1beta1.ModelSelectionSpec{Tag: &mlflowv1beta1.ModelTagSelector{Key: "team", Value: "fraud"}},
+			expectedTags:   map[string]string{"team": "fraud"},
+		},
+		{
+			name:           "tag without value",
+			modelSelection: &mlflowv1beta1.ModelSelectionSpec{Tag: &mlflowv1beta1.ModelTagSelector{Key: "team"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mlflowServerConfig := newTestMLFlow()
+			mlflowServerConfig.Spec.ModelSelection = tt.modelSelection
+
+			options := scanOptions(mlflowServerConfig)
+			if !reflect.DeepEqual(options.Tags, tt.expectedTags) {
+				t.Errorf("scanOptions().Tags = %v, want %v", options.Tags, tt.expectedTags)
+			}
+			if options.Concurrency != defaultModelSyncConcurrency {
+				t.Errorf("scanOptions().Concurrency = %d, want %d", options.Concurrency, defaultModelSyncConcurrency)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ValidateBackendStoreSecret checks that the credentials Secret of the backend store exists and holds the referenced keys
+// with values which can be inserted into the database URI
+func (r *MLFlowReconciler) ValidateBackendStoreSecret(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) error {
+	backendStore := mlflowServerConfig.Spec.BackendStore
+	if backendStore == nil || backendStore.CredentialsSecret == nil {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.getSecret(ctx, backendStore.CredentialsSecret.Name, mlflowServerConfig.Namespace, secret); err != nil {
+		return fmt.Errorf("unable to get backend store credentials secret %q: %w", backendStore.CredentialsSecret.Name, err)
+	}
+
+	usernameKey, passwordKey := mlflow.BackendStoreSecretKeys(backendStore.CredentialsSecret)
+	for _, key := range []string{usernameKey, passwordKey} {
+		value, ok := secret.Data[key]
+		if !ok {
+			return fmt.Errorf("backend store credentials secret %q has no key %q", secret.Name, key)
+		}
+		if err := mlflow.ValidateBackendStoreCredential(string(value)); err != nil {
+			return fmt.Errorf("backend store credentials secret %q key %q: %w", secret.Name, key, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *MLFlowReconciler) getSecret(ctx context.Context, name string, namespace string, secret *corev1.Secret) error {
+	namespacedName := types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}
+	return r.K8sClient.Get(ctx, namespacedName, secret)
+}
@@ -2,22 +2,31 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
-func (r *MLFlowReconciler) CreateOrUpdateService(ctx context.Context, service *corev1.Service) (*corev1.Service, error) {
+func (r *MLFlowReconciler) CreateOrUpdateService(ctx context.Context, owner metav1.Object, service *corev1.Service) (*corev1.Service, error) {
+	return createOrUpdateService(ctx, r.K8sClient, owner, service)
+}
+
+// createOrUpdateService creates the service or updates the existing one, refusing to take over a service
+// which is not controlled by the owner
+func createOrUpdateService(ctx context.Context, k8sClient client.Client, owner metav1.Object, service *corev1.Service) (*corev1.Service, error) {
 	logger := log.FromContext(ctx)
 	existService := &corev1.Service{}
-	err := r.getMLFlowService(ctx, service.Name, service.Namespace, existService)
+	err := getService(ctx, k8sClient, service.Name, service.Namespace, existService)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			err = r.K8sClient.Create(ctx, service)
+			err = k8sClient.Create(ctx, service)
 			if err != nil {
 				return nil, err
 			}
@@ -26,27 +35,51 @@ func (r *MLFlowReconciler) CreateOrUpdateService(ctx context.Context, service *c
 		return nil, err
 	}
 
-	if r.isThereAnyChangeOnService(service, existService) {
+	if !metav1.IsControlledBy(existService, owner) {
+		return nil, notControlledError("service", existService, owner)
+	}
+
+	if isThereAnyChangeOnService(service, existService) {
 		logger.Info("Updating Service")
 		existService.Spec = service.Spec
-		err := r.K8sClient.Update(ctx, existService)
+		existService.Labels = mergeStringMaps(existService.Labels, service.Labels)
+		existService.Annotations = mergeStringMaps(existService.Annotations, service.Annotations)
+		err := k8sClient.Update(ctx, existService)
 		if err != nil {
 			return nil, err
 		}
-		return service, nil
+		return existService, nil
 	}
 
 	return existService, nil
 }
 
-func (r *MLFlowReconciler) getMLFlowService(ctx context.Context, name string, namespace string, service *corev1.Service) error {
+func getService(ctx context.Context, k8sClient client.Client, name string, namespace string, service *corev1.Service) error {
 	namespacedName := types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}
-	return r.K8sClient.Get(ctx, namespacedName, service)
+	return k8sClient.Get(ctx, namespacedName, service)
+}
+
+func isThereAnyChangeOnService(oldService *corev1.Service, currentService *corev1.Service) bool {
+	return !equality.Semantic.DeepDerivative(oldService.Spec, currentService.Spec) ||
+		!equality.Semantic.DeepDerivative(oldService.Labels, currentService.Labels) ||
+		!equality.Semantic.DeepDerivative(oldService.Annotations, currentService.Annotations)
+}
+
+// notControlledError reports an existing object with the name of a desired object, which belongs to something else
+func notControlledError(kind string, existing metav1.Object, owner metav1.Object) error {
+	return fmt.Errorf("%s %q already exists and is not controlled by %q", kind, existing.GetName(), owner.GetName())
 }
 
-func (r *MLFlowReconciler) isThereAnyChangeOnService(oldService *corev1.Service, currentService *corev1.Service) bool {
-	return !equality.Semantic.DeepDerivative(oldService.Spec, currentService.Spec)
+// mergeStringMaps sets the entries of desired on existing, keeping the entries added by others
+func mergeStringMaps(existing map[string]string, desired map[string]string) map[string]string {
+	if existing == nil {
+		existing = make(map[string]string, len(desired))
+	}
+	for key, value := range desired {
+		existing[key] = value
+	}
+	return existing
 }
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// UpdateStatus applies the mutation on the latest version of the MLFlow instance and updates its status,
+// retrying on conflicts since the model sync updates the same status concurrently
+func (r *MLFlowReconciler) UpdateStatus(
+	ctx context.Context,
+	mlflowServerConfig *mlflowv1beta1.MLFlow,
+	mutate func(status *mlflowv1beta1.MLFlowStatus),
+) error {
+	key := client.ObjectKeyFromObject(mlflowServerConfig)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &mlflowv1beta1.MLFlow{}
+		if err := r.GetMlflowCRD(ctx, key, latest); err != nil {
+			return err
+		}
+
+		original := latest.Status.DeepCopy()
+		mutate(&latest.Status)
+		latest.Status.ServedModelCount = int32(len(latest.Status.Models))
+		latest.Status.Phase = phaseFromConditions(latest.Status.Conditions)
+
+		if !equality.Semantic.DeepEqual(original, &latest.Status) {
+			if err := r.K8sClient.Status().Update(ctx, latest); err != nil {
+				return err
+			}
+		}
+
+		latest.DeepCopyInto(mlflowServerConfig)
+		return nil
+	})
+}
+
+// UpdateConditions records the conditions observed while reconciling the given generation of the MLFlow instance
+func (r *MLFlowReconciler) UpdateConditions(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, conditions ...metav1.Condition) error {
+	generation := mlflowServerConfig.Generation
+
+	return r.UpdateStatus(ctx, mlflowServerConfig, func(status *mlflowv1beta1.MLFlowStatus) {
+		status.ObservedGeneration = generation
+		setConditions(&status.Conditions, generation, conditions...)
+	})
+}
+
+func setConditions(statusConditions *[]metav1.Condition, generation int64, conditions ...metav1.Condition) {
+	for _, condition := range conditions {
+		condition.ObservedGeneration = generation
+		meta.SetStatusCondition(statusConditions, condition)
+	}
+}
+
+func phaseFromConditions(conditions []metav1.Condition) mlflowv1beta1.Phase {
+	switch {
+	case meta.IsStatusConditionTrue(conditions, mlflowv1beta1.ConditionTypeDegraded):
+		return mlflowv1beta1.PhaseDegraded
+	case meta.IsStatusConditionTrue(conditions, mlflowv1beta1.ConditionTypeReady):
+		return mlflowv1beta1.PhaseReady
+	case meta.IsStatusConditionTrue(conditions, mlflowv1beta1.ConditionTypeProgressing):
+		return mlflowv1beta1.PhaseProgressing
+	default:
+		return mlflowv1beta1.PhasePending
+	}
+}
+
+func degradedConditions(reason string, err error) []metav1.Condition {
+	return []metav1.Condition{
+		{
+			Type:    mlflowv1beta1.ConditionTypeDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: err.Error(),
+		},
+		{
+			Type:    mlflowv1beta1.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		},
+		{
+			Type:   mlflowv1beta1.ConditionTypeProgressing,
+			Status: metav1.ConditionFalse,
+			Reason: reason,
+		},
+	}
+}
+
+// degrade marks the MLFlow instance as degraded and returns the causing error to requeue the reconciliation
+func (r *MLFlowReconciler) degrade(ctx context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow, reason string, err error) error {
+	if statusErr := r.UpdateConditions(ctx, mlflowServerConfig, degradedConditions(reason, err)...); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "unable to update status", "reason", reason)
+	}
+	return err
+}
+
+func deploymentConditions(deploymentIsNotReady bool) []metav1.Condition {
+	if deploymentIsNotReady {
+		return []metav1.Condition{
+			{
+				Type:   mlflowv1beta1.ConditionTypeDegraded,
+				Status: metav1.ConditionFalse,
+				Reason: mlflowv1beta1.ReasonReconciled,
+			},
+			{
+				Type:    mlflowv1beta1.ConditionTypeProgressing,
+				Status:  metav1.ConditionTrue,
+				Reason:  mlflowv1beta1.ReasonDeploymentNotReady,
+				Message: "Waiting for the MLflow server pods to become ready",
+			},
+			{
+				Type:    mlflowv1beta1.ConditionTypeReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  mlflowv1beta1.ReasonDeploymentNotReady,
+				Message: "Waiting for the MLflow server pods to become ready",
+			},
+		}
+	}
+
+	return []metav1.Condition{
+		{
+			Type:   mlflowv1beta1.ConditionTypeDegraded,
+			Status: metav1.ConditionFalse,
+			Reason: mlflowv1beta1.ReasonReconciled,
+		},
+		{
+			Type:   mlflowv1beta1.ConditionTypeProgressing,
+			Status: metav1.ConditionFalse,
+			Reason: mlflowv1beta1.ReasonDeploymentReady,
+		},
+		{
+			Type:   mlflowv1beta1.ConditionTypeReady,
+			Status: metav1.ConditionTrue,
+			Reason: mlflowv1beta1.ReasonDeploymentReady,
+		},
+	}
+}
+
+// setModelStatus inserts or replaces the serving state of a model deployment, moving its transition time only when the state changes
+func setModelStatus(status *mlflowv1beta1.MLFlowStatus, modelStatus mlflowv1beta1.ModelStatus) {
+	modelStatus.LastTransitionTime = metav1.Now()
+
+	for i := range status.Models {
+		existing := &status.Models[i]
+		if existing.DeploymentName != modelStatus.DeploymentName {
+			continue
+		}
+
+		if modelStatus.LastError != "" && modelStatus.Replicas == 0 {
+			modelStatus.Replicas = existing.Replicas
+			modelStatus.ReadyReplicas = existing.ReadyReplicas
+		}
+		if !isModelStateChanged(existing, &modelStatus) {
+			modelStatus.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = modelStatus
+		return
+	}
+
+	status.Models = append(status.Models, modelStatus)
+}
+
+func findModelStatus(status *mlflowv1beta1.MLFlowStatus, deploymentName string) *mlflowv1beta1.ModelStatus {
+	for i := range status.Models {
+		if status.Models[i].DeploymentName == deploymentName {
+			return &status.Models[i]
+		}
+	}
+	return nil
+}
+
+func removeModelStatus(status *mlflowv1beta1.MLFlowStatus, deploymentName string) {
+	models := status.Models[:0]
+	for _, modelStatus := range status.Models {
+		if modelStatus.DeploymentName != deploymentName {
+			models = append(models, modelStatus)
+		}
+	}
+	status.Models = models
+}
+
+func isModelReady(modelStatus *mlflowv1beta1.ModelStatus) bool {
+	return modelStatus.Replicas > 0 && modelStatus.ReadyReplicas == modelStatus.Replicas
+}
+
+func isModelStateChanged(oldStatus *mlflowv1beta1.ModelStatus, newStatus *mlflowv1beta1.ModelStatus) bool {
+	return oldStatus.Version != newStatus.Version ||
+		oldStatus.LastError != newStatus.LastError ||
+		isModelReady(oldStatus) != isModelReady(newStatus)
+}
@@ -0,0 +1,193 @@
+package mlflow
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultAccessKeyIDKey     = "accessKeyId"
+	defaultSecretAccessKeyKey = "secretAccessKey"
+	defaultArtifactsMountPath = "/mlartifacts"
+
+	artifactStoreVolumeName  = "artifact-store"
+	s3CABundleVolumeName     = "s3-ca-bundle"
+	s3CABundleMountPath      = "/etc/mlflow/s3-ca"
+	gcsCredentialsVolumeName = "gcs-credentials"
+	gcsCredentialsMountPath  = "/etc/mlflow/gcs"
+)
+
+type ArtifactStoreConfig struct {
+	Destination  string
+	Env          []corev1.EnvVar
+	Volumes      []corev1.Volume
+	VolumeMounts []corev1.VolumeMount
+}
+
+func (om *ObjectManager) CreateArtifactStoreConfig(artifactStore *mlflowv1beta1.ArtifactStoreSpec) (*ArtifactStoreConfig, error) {
+	switch {
+	case artifactStore.S3 != nil:
+		return om.createS3ArtifactStoreConfig(artifactStore.S3), nil
+	case artifactStore.GCS != nil:
+		return om.createGCSArtifactStoreConfig(artifactStore.GCS), nil
+	case artifactStore.AzureBlob != nil:
+		return om.createAzureBlobArtifactStoreConfig(artifactStore.AzureBlob), nil
+	case artifactStore.PVC != nil:
+		return om.createPVCArtifactStoreConfig(artifactStore.PVC), nil
+	default:
+		return nil, fmt.Errorf("artifact store has no store configured")
+	}
+}
+
+func (om *ObjectManager) createS3ArtifactStoreConfig(s3 *mlflowv1beta1.S3ArtifactStore) *ArtifactStoreConfig {
+	config := &ArtifactStoreConfig{
+		Destination: bucketURI("s3", s3.Bucket, s3.Path),
+	}
+
+	if s3.CredentialsSecret != nil {
+		accessKeyIDKey, secretAccessKeyKey := s3.CredentialsSecret.AccessKeyIDKey, s3.CredentialsSecret.SecretAccessKeyKey
+		if accessKeyIDKey == "" {
+			accessKeyIDKey = defaultAccessKeyIDKey
+		}
+		if secretAccessKeyKey == "" {
+			secretAccessKeyKey = defaultSecretAccessKeyKey
+		}
+		config.Env = append(config.Env,
+			corev1.EnvVar{
+				Name:      "AWS_ACCESS_KEY_ID",
+				ValueFrom: secretKeyRef(s3.CredentialsSecret.Name, accessKeyIDKey),
+			},
+			corev1.EnvVar{
+				Name:      "AWS_SECRET_ACCESS_KEY",
+				ValueFrom: secretKeyRef(s3.CredentialsSecret.Name, secretAccessKeyKey),
+			},
+		)
+	}
+
+	if s3.Endpoint != "" {
+		config.Env = append(config.Env, corev1.EnvVar{Name: "MLFLOW_S3_ENDPOINT_URL", Value: s3.Endpoint})
+	}
+
+	if s3.Region != "" {
+		config.Env = append(config.Env, corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: s3.Region})
+	}
+
+	if s3.InsecureSkipTLSVerify {
+		config.Env = append(config.Env, corev1.EnvVar{Name: "MLFLOW_S3_IGNORE_TLS", Value: "true"})
+	}
+
+	if s3.CABundleSecret != nil {
+		config.Env = append(config.Env, corev1.EnvVar{
+			Name:  "AWS_CA_BUNDLE",
+			Value: path.Join(s3CABundleMountPath, s3.CABundleSecret.Key),
+		})
+		config.Volumes = append(config.Volumes, secretVolume(s3CABundleVolumeName, s3.CABundleSecret))
+		config.VolumeMounts = append(config.VolumeMounts, corev1.VolumeMount{
+			Name:      s3CABundleVolumeName,
+			MountPath: s3CABundleMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return config
+}
+
+func (om *ObjectManager) createGCSArtifactStoreConfig(gcs *mlflowv1beta1.GCSArtifactStore) *ArtifactStoreConfig {
+	config := &ArtifactStoreConfig{
+		Destination: bucketURI("gs", gcs.Bucket, gcs.Path),
+	}
+
+	if gcs.CredentialsSecret != nil {
+		config.Env = append(config.Env, corev1.EnvVar{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: path.Join(gcsCredentialsMountPath, gcs.CredentialsSecret.Key),
+		})
+		config.Volumes = append(config.Volumes, secretVolume(gcsCredentialsVolumeName, gcs.CredentialsSecret))
+		config.VolumeMounts = append(config.VolumeMounts, corev1.VolumeMount{
+			Name:      gcsCredentialsVolumeName,
+			MountPath: gcsCredentialsMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return config
+}
+
+func (om *ObjectManager) createAzureBlobArtifactStoreConfig(azureBlob *mlflowv1beta1.AzureBlobArtifactStore) *ArtifactStoreConfig {
+	config := &ArtifactStoreConfig{
+		Destination: bucketURI("wasbs", fmt.Sprintf("%s@%s.blob.core.windows.net", azureBlob.Container, azureBlob.StorageAccount), azureBlob.Path),
+	}
+
+	if azureBlob.ConnectionStringSecret != nil {
+		config.Env = append(config.Env, corev1.EnvVar{
+			Name:      "AZURE_STORAGE_CONNECTION_STRING",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: azureBlob.ConnectionStringSecret.DeepCopy()},
+		})
+	}
+
+	if azureBlob.AccessKeySecret != nil {
+		config.Env = append(config.Env, corev1.EnvVar{
+			Name:      "AZURE_STORAGE_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: azureBlob.AccessKeySecret.DeepCopy()},
+		})
+	}
+
+	return config
+}
+
+func (om *ObjectManager) createPVCArtifactStoreConfig(pvc *mlflowv1beta1.PVCArtifactStore) *ArtifactStoreConfig {
+	mountPath := pvc.MountPath
+	if mountPath == "" {
+		mountPath = defaultArtifactsMountPath
+	}
+
+	return &ArtifactStoreConfig{
+		Destination: mountPath,
+		Volumes: []corev1.Volume{
+			{
+				Name: artifactStoreVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvc.ClaimName,
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      artifactStoreVolumeName,
+				MountPath: mountPath,
+				SubPath:   pvc.SubPath,
+			},
+		},
+	}
+}
+
+func bucketURI(scheme string, bucket string, prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return fmt.Sprintf("%s://%s", scheme, bucket)
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, bucket, prefix)
+}
+
+func secretVolume(name string, selector *corev1.SecretKeySelector) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: selector.Name,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  selector.Key,
+						Path: selector.Key,
+					},
+				},
+			},
+		},
+	}
+}
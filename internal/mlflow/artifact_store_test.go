@@ -0,0 +1,115 @@
+package mlflow
+
+import (
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestObjectManager_CreateArtifactStoreConfig(t *testing.T) {
+	tests := []struct {
+		artifactStore    *mlflowv1beta1.ArtifactStoreSpec
+		name             string
+		wantDestination  string
+		wantEnvNames     []string
+		wantVolumeMounts int
+		wantErr          bool
+	}{
+		{
+			name: "should return s3 destination with endpoint and credentials",
+			artifactStore: &mlflowv1beta1.ArtifactStoreSpec{
+				S3: &mlflowv1beta1.S3ArtifactStore{
+					Bucket:                "team-a",
+					Path:                  "/tracking/",
+					Endpoint:              "https://minio.minio.svc.cluster.local",
+					InsecureSkipTLSVerify: true,
+					CredentialsSecret:     &mlflowv1beta1.AccessKeySecretReference{Name: "minio"},
+				},
+			},
+			wantDestination: "s3://team-a/tracking",
+			wantEnvNames:    []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "MLFLOW_S3_ENDPOINT_URL", "MLFLOW_S3_IGNORE_TLS"},
+		},
+		{
+			name: "should mount s3 ca bundle",
+			artifactStore: &mlflowv1beta1.ArtifactStoreSpec{
+				S3: &mlflowv1beta1.S3ArtifactStore{
+					Bucket: "team-a",
+					CABundleSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "ca"},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+			wantDestination:  "s3://team-a",
+			wantEnvNames:     []string{"AWS_CA_BUNDLE"},
+			wantVolumeMounts: 1,
+		},
+		{
+			name: "should return gcs destination with mounted credentials",
+			artifactStore: &mlflowv1beta1.ArtifactStoreSpec{
+				GCS: &mlflowv1beta1.GCSArtifactStore{
+					Bucket: "team-b",
+					CredentialsSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "gcs"},
+						Key:                  "key.json",
+					},
+				},
+			},
+			wantDestination:  "gs://team-b",
+			wantEnvNames:     []string{"GOOGLE_APPLICATION_CREDENTIALS"},
+			wantVolumeMounts: 1,
+		},
+		{
+			name: "should return azure blob destination",
+			artifactStore: &mlflowv1beta1.ArtifactStoreSpec{
+				AzureBlob: &mlflowv1beta1.AzureBlobArtifactStore{
+					Container:      "artifacts",
+					StorageAccount: "teamc",
+					Path:           "mlflow",
+				},
+			},
+			wantDestination: "wasbs://artifacts@teamc.blob.core.windows.net/mlflow",
+		},
+		{
+			name: "should return pvc mount path as destination",
+			artifactStore: &mlflowv1beta1.ArtifactStoreSpec{
+				PVC: &mlflowv1beta1.PVCArtifactStore{ClaimName: "artifacts"},
+			},
+			wantDestination:  "/mlartifacts",
+			wantVolumeMounts: 1,
+		},
+		{
+			name:          "should return error if no store is configured",
+			artifactStore: &mlflowv1beta1.ArtifactStoreSpec{},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om := &ObjectManager{}
+			got, err := om.CreateArtifactStoreConfig(tt.artifactStore)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateArtifactStoreConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Destination != tt.wantDestination {
+				t.Errorf("CreateArtifactStoreConfig() destination = %v, want %v", got.Destination, tt.wantDestination)
+			}
+			if len(got.Env) != len(tt.wantEnvNames) {
+				t.Fatalf("CreateArtifactStoreConfig() env = %v, want %v", got.Env, tt.wantEnvNames)
+			}
+			for i, env := range got.Env {
+				if env.Name != tt.wantEnvNames[i] {
+					t.Errorf("CreateArtifactStoreConfig() env[%d] = %v, want %v", i, env.Name, tt.wantEnvNames[i])
+				}
+			}
+			if len(got.VolumeMounts) != tt.wantVolumeMounts || len(got.Volumes) != tt.wantVolumeMounts {
+				t.Errorf("CreateArtifactStoreConfig() volumes = %v, mounts = %v, want %d", got.Volumes, got.VolumeMounts, tt.wantVolumeMounts)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package mlflow
+
+import (
+	"fmt"
+	"strconv"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultSecretUsernameKey = "username"
+	defaultSecretPasswordKey = "password"
+)
+
+var defaultBackendStorePorts = map[mlflowv1beta1.BackendStoreType]int32{
+	mlflowv1beta1.BackendStorePostgreSQL: 5432,
+	mlflowv1beta1.BackendStoreMySQL:      3306,
+	mlflowv1beta1.BackendStoreMSSQL:      1433,
+}
+
+func BackendStoreSecretKeys(ref *mlflowv1beta1.CredentialsSecretReference) (usernameKey string, passwordKey string) {
+	usernameKey, passwordKey = ref.UsernameKey, ref.PasswordKey
+	if usernameKey == "" {
+		usernameKey = defaultSecretUsernameKey
+	}
+	if passwordKey == "" {
+		passwordKey = defaultSecretPasswordKey
+	}
+	return
+}
+
+// ValidateBackendStoreCredential checks that a username or password can be inserted into the database URI,
+// the characters reserved in URIs have to be percent-encoded
+func ValidateBackendStoreCredential(value string) error {
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '@', ':', '/', '?', '#':
+			return fmt.Errorf("must percent-encode %q", value[i])
+		case '%':
+			if i+2 >= len(value) || !isHex(value[i+1]) || !isHex(value[i+2]) {
+				return fmt.Errorf("must percent-encode %q", value[i])
+			}
+		}
+	}
+	return nil
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func (om *ObjectManager) CreateBackendStoreURI(backendStore *mlflowv1beta1.BackendStoreSpec) (string, error) {
+	scheme := string(backendStore.Type)
+	if backendStore.Driver != "" {
+		scheme = fmt.Sprintf("%s+%s", scheme, backendStore.Driver)
+	}
+
+	switch backendStore.Type {
+	case mlflowv1beta1.BackendStoreSQLite:
+		return fmt.Sprintf("%s:///%s", scheme, backendStore.Database), nil
+	case mlflowv1beta1.BackendStorePostgreSQL, mlflowv1beta1.BackendStoreMySQL, mlflowv1beta1.BackendStoreMSSQL:
+		if backendStore.Host == "" {
+			return "", fmt.Errorf("host is required for %s backend store", backendStore.Type)
+		}
+		if backendStore.CredentialsSecret == nil {
+			return fmt.Sprintf("%s://$(DB_HOST):$(DB_PORT)/$(DB_NAME)", scheme), nil
+		}
+		return fmt.Sprintf("%s://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)", scheme), nil
+	default:
+		return "", fmt.Errorf("unsupported backend store type %q", backendStore.Type)
+	}
+}
+
+func (om *ObjectManager) CreateBackendStoreEnv(backendStore *mlflowv1beta1.BackendStoreSpec) []corev1.EnvVar {
+	if backendStore.Type == mlflowv1beta1.BackendStoreSQLite {
+		return nil
+	}
+
+	port := backendStore.Port
+	if port == 0 {
+		port = defaultBackendStorePorts[backendStore.Type]
+	}
+
+	var env []corev1.EnvVar
+	if backendStore.CredentialsSecret != nil {
+		usernameKey, passwordKey := BackendStoreSecretKeys(backendStore.CredentialsSecret)
+		env = append(env,
+			corev1.EnvVar{
+				Name:      "DB_USER",
+				ValueFrom: secretKeyRef(backendStore.CredentialsSecret.Name, usernameKey),
+			},
+			corev1.EnvVar{
+				Name:      "DB_PASSWORD",
+				ValueFrom: secretKeyRef(backendStore.CredentialsSecret.Name, passwordKey),
+			},
+		)
+	}
+
+	return append(env,
+		corev1.EnvVar{
+			Name:  "DB_HOST",
+			Value: backendStore.Host,
+		},
+		corev1.EnvVar{
+			Name:  "DB_PORT",
+			Value: strconv.Itoa(int(port)),
+		},
+		corev1.EnvVar{
+			Name:  "DB_NAME",
+			Value: backendStore.Database,
+		},
+	)
+}
+
+func secretKeyRef(name string, key string) *corev1.EnvVarSource {
+	return &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		},
+	}
+}
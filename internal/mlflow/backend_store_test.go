@@ -0,0 +1,116 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestObjectManager_CreateBackendStoreURI(t *testing.T) {
+	tests := []struct {
+		backendStore *mlflowv1beta1.BackendStoreSpec
+		name         string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name: "should return postgresql uri with credentials",
+			backendStore: &mlflowv1beta1.BackendStoreSpec{
+				Type:              mlflowv1beta1.BackendStorePostgreSQL,
+				Host:              "postgres.postgres",
+				Database:          "mlflow",
+				CredentialsSecret: &mlflowv1beta1.CredentialsSecretReference{Name: "db"},
+			},
+			want: "postgresql://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)",
+		},
+		{
+			name: "should return mysql uri with driver and without credentials",
+			backendStore: &mlflowv1beta1.BackendStoreSpec{
+				Type:     mlflowv1beta1.BackendStoreMySQL,
+				Driver:   "pymysql",
+				Host:     "mysql",
+				Database: "mlflow",
+			},
+			want: "mysql+pymysql://$(DB_HOST):$(DB_PORT)/$(DB_NAME)",
+		},
+		{
+			name: "should return sqlite uri with database path",
+			backendStore: &mlflowv1beta1.BackendStoreSpec{
+				Type:     mlflowv1beta1.BackendStoreSQLite,
+				Database: "/mlruns/mlflow.db",
+			},
+			want: "sqlite:////mlruns/mlflow.db",
+		},
+		{
+			name: "should return error if host is missing",
+			backendStore: &mlflowv1beta1.BackendStoreSpec{
+				Type:     mlflowv1beta1.BackendStoreMSSQL,
+				Database: "mlflow",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om := &ObjectManager{}
+			got, err := om.CreateBackendStoreURI(tt.backendStore)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateBackendStoreURI() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CreateBackendStoreURI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectManager_CreateBackendStoreEnv(t *testing.T) {
+	om := &ObjectManager{}
+	env := om.CreateBackendStoreEnv(&mlflowv1beta1.BackendStoreSpec{
+		Type:     mlflowv1beta1.BackendStoreMySQL,
+		Host:     "mysql",
+		Database: "mlflow",
+		CredentialsSecret: &mlflowv1beta1.CredentialsSecretReference{
+			Name:        "db",
+			PasswordKey: "pass",
+		},
+	})
+
+	want := []corev1.EnvVar{
+		{Name: "DB_USER", ValueFrom: secretKeyRef("db", "username")},
+		{Name: "DB_PASSWORD", ValueFrom: secretKeyRef("db", "pass")},
+		{Name: "DB_HOST", Value: "mysql"},
+		{Name: "DB_PORT", Value: "3306"},
+		{Name: "DB_NAME", Value: "mlflow"},
+	}
+
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("CreateBackendStoreEnv() = %v, want %v", env, want)
+	}
+}
+
+func TestValidateBackendStoreCredential(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "plain", value: "s3cr3t-Pass_word.~!"},
+		{name: "percent-encoded", value: "p%40ss%3Aword"},
+		{name: "at sign", value: "p@ss", wantErr: true},
+		{name: "colon", value: "p:ss", wantErr: true},
+		{name: "slash", value: "p/ss", wantErr: true},
+		{name: "bare percent", value: "100%", wantErr: true},
+		{name: "invalid escape", value: "p%zzss", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateBackendStoreCredential(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBackendStoreCredential() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
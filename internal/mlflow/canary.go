@@ -0,0 +1,52 @@
+package mlflow
+
+import (
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+const defaultCanaryStepInterval = 5 * time.Minute
+
+var defaultCanaryWeights = []int32{10, 50}
+
+// CanaryWeights returns the traffic weights of the canary steps of a new model version
+func CanaryWeights(canary *mlflowv1beta1.CanarySpec) []int32 {
+	if canary == nil || len(canary.Weights) == 0 {
+		return defaultCanaryWeights
+	}
+	return canary.Weights
+}
+
+// CanaryStepInterval returns the time a new model version serves every canary weight
+func CanaryStepInterval(canary *mlflowv1beta1.CanarySpec) time.Duration {
+	if canary == nil || canary.StepIntervalInMinutes <= 0 {
+		return defaultCanaryStepInterval
+	}
+	return time.Minute * time.Duration(canary.StepIntervalInMinutes)
+}
+
+// NextCanaryWeight returns the first canary weight above the given weight, or 100 after the last step
+func NextCanaryWeight(weights []int32, weight int32) int32 {
+	for _, next := range weights {
+		if next > weight {
+			return next
+		}
+	}
+	return 100
+}
+
+// CanaryReplicas returns the replicas of the new model version which receive about weight percent of the traffic
+// when they are shared behind a Service with the given replicas of the serving version
+func CanaryReplicas(replicas int32, weight int32) int32 {
+	if weight >= 100 {
+		return replicas
+	}
+	canaryReplicas := (replicas*weight + 100 - weight - 1) / (100 - weight)
+	return max(canaryReplicas, 1)
+}
+
+// SharedModelServiceSelector selects the pods of every version deployment promoted behind the Service of an alias
+func SharedModelServiceSelector(serviceName string) map[string]string {
+	return map[string]string{ModelServiceLabelKey: serviceName}
+}
@@ -0,0 +1,50 @@
+package mlflow
+
+import (
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+func TestNextCanaryWeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		weights  []int32
+		weight   int32
+		expected int32
+	}{
+		{name: "first step", weights: CanaryWeights(nil), weight: 0, expected: 10},
+		{name: "second step", weights: CanaryWeights(nil), weight: 10, expected: 50},
+		{name: "after last step", weights: CanaryWeights(nil), weight: 50, expected: 100},
+		{name: "custom steps", weights: CanaryWeights(&mlflowv1beta1.CanarySpec{Weights: []int32{5, 25, 75}}), weight: 25, expected: 75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextCanaryWeight(tt.weights, tt.weight); got != tt.expected {
+				t.Errorf("NextCanaryWeight() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanaryReplicas(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas int32
+		weight   int32
+		expected int32
+	}{
+		{name: "at least one replica", replicas: 2, weight: 10, expected: 1},
+		{name: "equal split", replicas: 3, weight: 50, expected: 3},
+		{name: "proportional", replicas: 9, weight: 10, expected: 1},
+		{name: "rounded up", replicas: 4, weight: 75, expected: 12},
+		{name: "promoted", replicas: 3, weight: 100, expected: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanaryReplicas(tt.replicas, tt.weight); got != tt.expected {
+				t.Errorf("CanaryReplicas() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
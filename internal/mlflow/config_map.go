@@ -0,0 +1,66 @@
+package mlflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ConfigHashAnnotationKey = "mlflow.trendyol.com/config-hash"
+)
+
+// serverFlagConfigKeys maps the recognised ConfigMap keys to the mlflow server flags they configure
+var serverFlagConfigKeys = map[string]string{
+	"server.workers":          "--workers",
+	"server.gunicornOpts":     "--gunicorn-opts",
+	"server.staticPrefix":     "--static-prefix",
+	"server.appName":          "--app-name",
+	"server.exposePrometheus": "--expose-prometheus",
+}
+
+func (om *ObjectManager) CreateServerArgsFromConfigMap(configMap *corev1.ConfigMap) []string {
+	keys := make([]string, 0, len(serverFlagConfigKeys))
+	for key := range serverFlagConfigKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, key := range keys {
+		if value, ok := configMap.Data[key]; ok && value != "" {
+			args = append(args, serverFlagConfigKeys[key], value)
+		}
+	}
+
+	return args
+}
+
+// ConfigMapHash returns a stable hash of the ConfigMap content, used to roll the pods when the content changes
+func (om *ObjectManager) ConfigMapHash(configMap *corev1.ConfigMap) string {
+	hash := sha256.New()
+
+	keys := make([]string, 0, len(configMap.Data)+len(configMap.BinaryData))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	for key := range configMap.BinaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		if value, ok := configMap.Data[key]; ok {
+			hash.Write([]byte(value))
+		} else {
+			hash.Write(configMap.BinaryData[key])
+		}
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,39 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestObjectManager_CreateServerArgsFromConfigMap(t *testing.T) {
+	om := &ObjectManager{}
+	configMap := &corev1.ConfigMap{
+		Data: map[string]string{
+			"server.workers":      "4",
+			"server.staticPrefix": "/mlflow",
+			"server.appName":      "",
+			"MLFLOW_UNRELATED":    "value",
+		},
+	}
+
+	want := []string{"--static-prefix", "/mlflow", "--workers", "4"}
+	if got := om.CreateServerArgsFromConfigMap(configMap); !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateServerArgsFromConfigMap() = %v, want %v", got, want)
+	}
+}
+
+func TestObjectManager_ConfigMapHash(t *testing.T) {
+	om := &ObjectManager{}
+	configMap := &corev1.ConfigMap{Data: map[string]string{"a": "1", "b": "2"}}
+	sameContent := &corev1.ConfigMap{Data: map[string]string{"b": "2", "a": "1"}}
+	changedContent := &corev1.ConfigMap{Data: map[string]string{"a": "1", "b": "3"}}
+
+	if om.ConfigMapHash(configMap) != om.ConfigMapHash(sameContent) {
+		t.Errorf("Expected same hash for same content")
+	}
+	if om.ConfigMapHash(configMap) == om.ConfigMapHash(changedContent) {
+		t.Errorf("Expected different hash for changed content")
+	}
+}
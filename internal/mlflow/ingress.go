@@ -0,0 +1,342 @@
+package mlflow
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	modelPathPrefix = "/models"
+	// modelIngressNameSuffix is appended to the name of the MLFlow instance for the Ingress of the path routed models
+	modelIngressNameSuffix = "-models"
+	// nginxUseRegexAnnotationKey and nginxRewriteTargetAnnotationKey make ingress-nginx strip the model path prefix
+	nginxUseRegexAnnotationKey      = "nginx.ingress.kubernetes.io/use-regex"
+	nginxRewriteTargetAnnotationKey = "nginx.ingress.kubernetes.io/rewrite-target"
+
+	// HTTPRouteAPIVersion is the Gateway API version of the generated HTTPRoutes
+	HTTPRouteAPIVersion = "gateway.networking.k8s.io/v1"
+	// HTTPRouteKind is the kind of the generated HTTPRoutes
+	HTTPRouteKind = "HTTPRoute"
+)
+
+// ModelRoute routes external traffic to the Service of a served model
+type ModelRoute struct {
+	Path        string
+	Host        string
+	ServiceName string
+	// CanaryServiceName receives CanaryWeight percent of the traffic of the route, only supported by HTTPRoutes
+	CanaryServiceName string
+	CanaryWeight      int32
+}
+
+// CreateModelRoutes returns the routes of the models which have a Service, ordered by their Service name
+func (om *ObjectManager) CreateModelRoutes(config *mlflowv1beta1.MLFlow, models []mlflowv1beta1.ModelStatus) []ModelRoute {
+	routing := modelRouting(config.Spec.Ingress)
+	routes := make([]ModelRoute, 0, len(models))
+	for _, model := range models {
+		if model.Endpoint == "" {
+			continue
+		}
+
+		versionOrAlias := model.Version
+		if model.Alias != "" {
+			versionOrAlias = model.Alias
+		}
+
+		route := ModelRoute{
+			Path:        path.Join(modelPathPrefix, url.PathEscape(model.Name), url.PathEscape(versionOrAlias)),
+			ServiceName: model.DeploymentName,
+		}
+		if promotion := model.Promotion; promotion != nil && promotion.Phase == mlflowv1beta1.ModelPromotionCanary && promotion.Weight > 0 {
+			route.CanaryServiceName = promotion.DeploymentName
+			route.CanaryWeight = promotion.Weight
+		}
+		if routing == mlflowv1beta1.ModelRoutingHost {
+			route.Path = "/"
+			route.Host = model.DeploymentName + "." + config.Spec.Ingress.Host
+		}
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].ServiceName < routes[j].ServiceName
+	})
+	return routes
+}
+
+// modelRouting returns how the models are routed, defaulting to path routing
+func modelRouting(ingressSpec *mlflowv1beta1.IngressSpec) mlflowv1beta1.ModelRouting {
+	if ingressSpec.ModelRouting != "" {
+		return ingressSpec.ModelRouting
+	}
+	return mlflowv1beta1.ModelRoutingPath
+}
+
+// CreateMlflowIngressObject creates the Ingress routing to the MLFlow server and the given host routed models,
+// the path routed models are served by the Ingress created by CreateMlflowModelIngressObject
+func (om *ObjectManager) CreateMlflowIngressObject(config *mlflowv1beta1.MLFlow, routes []ModelRoute) (*networkingv1.Ingress, error) {
+	ingressSpec := config.Spec.Ingress
+	pathType := networkingv1.PathTypePrefix
+
+	serverRule := networkingv1.IngressRule{
+		Host: ingressSpec.Host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{},
+		},
+	}
+	rules := []networkingv1.IngressRule{serverRule}
+
+	var tlsHosts []string
+	if ingressSpec.Host != "" {
+		tlsHosts = append(tlsHosts, ingressSpec.Host)
+	}
+
+	for _, route := range routes {
+		if route.Host == "" {
+			continue
+		}
+
+		rules = append(rules, networkingv1.IngressRule{
+			Host: route.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+					Path:     route.Path,
+					PathType: &pathType,
+					Backend:  ingressBackend(route.ServiceName, ModelServicePort(config)),
+				}}},
+			},
+		})
+		tlsHosts = append(tlsHosts, route.Host)
+	}
+
+	rules[0].HTTP.Paths = append(rules[0].HTTP.Paths, networkingv1.HTTPIngressPath{
+		Path:     "/",
+		PathType: &pathType,
+		Backend:  ingressBackend(config.Name, ServerServicePort(config)),
+	})
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        config.Name,
+			Namespace:   config.Namespace,
+			Labels:      map[string]string{appLabelKey: config.Name},
+			Annotations: ingressSpec.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ingressSpec.ClassName,
+			Rules:            rules,
+		},
+	}
+	if ingressSpec.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: tlsHosts, SecretName: ingressSpec.TLSSecretName}}
+	}
+
+	if err := controllerutil.SetControllerReference(config, ingress, om.Scheme); err != nil {
+		return nil, err
+	}
+
+	return ingress, nil
+}
+
+// ModelIngressName returns the name of the Ingress of the path routed models
+func ModelIngressName(config *mlflowv1beta1.MLFlow) string {
+	return config.Name + modelIngressNameSuffix
+}
+
+// CreateMlflowModelIngressObject creates the Ingress of the path routed models, returning nil when no model is routed
+// by path. Ingress has no standard way to strip the model path prefix, so the paths are ingress-nginx regular
+// expressions rewritten to the path after the prefix, in an Ingress of their own to keep the server paths as they are.
+func (om *ObjectManager) CreateMlflowModelIngressObject(config *mlflowv1beta1.MLFlow, routes []ModelRoute) (*networkingv1.Ingress, error) {
+	ingressSpec := config.Spec.Ingress
+	pathType := networkingv1.PathTypeImplementationSpecific
+
+	var paths []networkingv1.HTTPIngressPath
+	for _, route := range routes {
+		if route.Host != "" {
+			continue
+		}
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     regexp.QuoteMeta(route.Path) + "(/|$)(.*)",
+			PathType: &pathType,
+			Backend:  ingressBackend(route.ServiceName, ModelServicePort(config)),
+		})
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	annotations := make(map[string]string, len(ingressSpec.Annotations)+2)
+	for key, value := range ingressSpec.Annotations {
+		annotations[key] = value
+	}
+	annotations[nginxUseRegexAnnotationKey] = "true"
+	annotations[nginxRewriteTargetAnnotationKey] = "/$2"
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ModelIngressName(config),
+			Namespace:   config.Namespace,
+			Labels:      map[string]string{appLabelKey: config.Name},
+			Annotations: annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ingressSpec.ClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: ingressSpec.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+				},
+			}},
+		},
+	}
+	if ingressSpec.TLSSecretName != "" && ingressSpec.Host != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{ingressSpec.Host}, SecretName: ingressSpec.TLSSecretName}}
+	}
+
+	if err := controllerutil.SetControllerReference(config, ingress, om.Scheme); err != nil {
+		return nil, err
+	}
+
+	return ingress, nil
+}
+
+// CreateMlflowHTTPRouteObject creates the Gateway API HTTPRoute routing to the MLFlow server and the given model routes,
+// stripping the model path prefix before it reaches the model server
+func (om *ObjectManager) CreateMlflowHTTPRouteObject(config *mlflowv1beta1.MLFlow, routes []ModelRoute) (*unstructured.Unstructured, error) {
+	ingressSpec := config.Spec.Ingress
+
+	parentRefs := make([]interface{}, 0, len(ingressSpec.ParentRefs))
+	for _, parentRef := range ingressSpec.ParentRefs {
+		ref := map[string]interface{}{"name": parentRef.Name}
+		if parentRef.Namespace != "" {
+			ref["namespace"] = parentRef.Namespace
+		}
+		if parentRef.SectionName != "" {
+			ref["sectionName"] = parentRef.SectionName
+		}
+		parentRefs = append(parentRefs, ref)
+	}
+
+	rules := make([]interface{}, 0, len(routes)+1)
+	for _, route := range routes {
+		rules = append(rules, map[string]interface{}{
+			"matches": []interface{}{pathPrefixMatch(route.Path)},
+			"filters": []interface{}{
+				map[string]interface{}{
+					"type": "URLRewrite",
+					"urlRewrite": map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":               "ReplacePrefixMatch",
+							"replacePrefixMatch": "/",
+						},
+					},
+				},
+			},
+			"backendRefs": httpRouteBackendRefs(route, ModelServicePort(config)),
+		})
+	}
+	rules = append(rules, map[string]interface{}{
+		"matches":     []interface{}{pathPrefixMatch("/")},
+		"backendRefs": []interface{}{httpRouteBackendRef(config.Name, ServerServicePort(config))},
+	})
+
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules":      rules,
+	}
+	if ingressSpec.Host != "" {
+		spec["hostnames"] = []interface{}{ingressSpec.Host}
+	}
+
+	httpRoute := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	httpRoute.SetAPIVersion(HTTPRouteAPIVersion)
+	httpRoute.SetKind(HTTPRouteKind)
+	httpRoute.SetName(config.Name)
+	httpRoute.SetNamespace(config.Namespace)
+	httpRoute.SetLabels(map[string]string{appLabelKey: config.Name})
+	httpRoute.SetAnnotations(ingressSpec.Annotations)
+
+	if err := controllerutil.SetControllerReference(config, httpRoute, om.Scheme); err != nil {
+		return nil, err
+	}
+
+	return httpRoute, nil
+}
+
+// ModelServicePort returns the port the Services of the models expose
+func ModelServicePort(config *mlflowv1beta1.MLFlow) int32 {
+	if config.Spec.ModelPort != 0 {
+		return config.Spec.ModelPort
+	}
+	return defaultModelPort
+}
+
+func ingressBackend(serviceName string, port int32) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: serviceName,
+			Port: networkingv1.ServiceBackendPort{Number: port},
+		},
+	}
+}
+
+func pathPrefixMatch(prefix string) map[string]interface{} {
+	return map[string]interface{}{
+		"path": map[string]interface{}{
+			"type":  "PathPrefix",
+			"value": prefix,
+		},
+	}
+}
+
+// httpRouteBackendRefs splits the traffic of the model route between its Service and its canary by their weights
+func httpRouteBackendRefs(route ModelRoute, port int32) []interface{} {
+	if route.CanaryServiceName == "" {
+		return []interface{}{httpRouteBackendRef(route.ServiceName, port)}
+	}
+
+	backendRef := httpRouteBackendRef(route.ServiceName, port)
+	backendRef["weight"] = int64(100 - route.CanaryWeight)
+	canaryBackendRef := httpRouteBackendRef(route.CanaryServiceName, port)
+	canaryBackendRef["weight"] = int64(route.CanaryWeight)
+	return []interface{}{backendRef, canaryBackendRef}
+}
+
+// HTTPRouteBackendWeight returns the weight of the backend of the HTTPRoute sending traffic to the Service
+func HTTPRouteBackendWeight(httpRoute *unstructured.Unstructured, serviceName string) (int32, bool) {
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		backendRefs, _, _ := unstructured.NestedSlice(ruleMap, "backendRefs")
+		for _, backendRef := range backendRefs {
+			backendRefMap, ok := backendRef.(map[string]interface{})
+			if !ok || backendRefMap["name"] != serviceName {
+				continue
+			}
+			weight, found, err := unstructured.NestedInt64(backendRefMap, "weight")
+			if err != nil || !found {
+				return 0, false
+			}
+			return int32(weight), true
+		}
+	}
+	return 0, false
+}
+
+func httpRouteBackendRef(serviceName string, port int32) map[string]interface{} {
+	return map[string]interface{}{
+		"name": serviceName,
+		"port": int64(port),
+	}
+}
@@ -0,0 +1,247 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newIngressTestObjectManager(t *testing.T) *ObjectManager {
+	scheme := runtime.NewScheme()
+	if err := mlflowv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	return &ObjectManager{Scheme: scheme}
+}
+
+func TestObjectManager_CreateModelRoutes(t *testing.T) {
+	om := &ObjectManager{}
+	models := []mlflowv1beta1.ModelStatus{
+		{Name: "fraud", Alias: "champion", DeploymentName: "mlflow-fraud-champion", Endpoint: "http://mlflow-fraud-champion.ml.svc.cluster.local:5000"},
+		{Name: "churn", Version: "2", DeploymentName: "mlflow-churn-2", Endpoint: "http://mlflow-churn-2.ml.svc.cluster.local:5000"},
+		{Name: "churn", Version: "3", DeploymentName: "mlflow-churn-3"},
+	}
+
+	tests := []struct {
+		name     string
+		kind     mlflowv1beta1.IngressKind
+		routing  mlflowv1beta1.ModelRouting
+		expected []ModelRoute
+	}{
+		{
+			name: "ingress defaults to path",
+			kind: mlflowv1beta1.IngressKindIngress,
+			expected: []ModelRoute{
+				{Path: "/models/churn/2", ServiceName: "mlflow-churn-2"},
+				{Path: "/models/fraud/champion", ServiceName: "mlflow-fraud-champion"},
+			},
+		},
+		{
+			name: "http route defaults to path",
+			kind: mlflowv1beta1.IngressKindHTTPRoute,
+			expected: []ModelRoute{
+				{Path: "/models/churn/2", ServiceName: "mlflow-churn-2"},
+				{Path: "/models/fraud/champion", ServiceName: "mlflow-fraud-champion"},
+			},
+		},
+		{
+			name:    "path",
+			routing: mlflowv1beta1.ModelRoutingPath,
+			expected: []ModelRoute{
+				{Path: "/models/churn/2", ServiceName: "mlflow-churn-2"},
+				{Path: "/models/fraud/champion", ServiceName: "mlflow-fraud-champion"},
+			},
+		},
+		{
+			name:    "host",
+			routing: mlflowv1beta1.ModelRoutingHost,
+			expected: []ModelRoute{
+				{Path: "/", Host: "mlflow-churn-2.mlflow.example.com", ServiceName: "mlflow-churn-2"},
+				{Path: "/", Host: "mlflow-fraud-champion.mlflow.example.com", ServiceName: "mlflow-fraud-champion"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &mlflowv1beta1.MLFlow{
+				Spec: mlflowv1beta1.MLFlowSpec{
+					Ingress: &mlflowv1beta1.IngressSpec{Host: "mlflow.example.com", Kind: tt.kind, ModelRouting: tt.routing},
+				},
+			}
+			if got := om.CreateModelRoutes(config, models); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("CreateModelRoutes() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestObjectManager_CreateMlflowIngressObject(t *testing.T) {
+	om := newIngressTestObjectManager(t)
+	config := &mlflowv1beta1.MLFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+		Spec: mlflowv1beta1.MLFlowSpec{
+			Ingress: &mlflowv1beta1.IngressSpec{Host: "mlflow.example.com", TLSSecretName: "mlflow-tls"},
+		},
+	}
+
+	ingress, err := om.CreateMlflowIngressObject(config, []ModelRoute{
+		{Path: "/models/churn/2", ServiceName: "mlflow-churn-2"},
+		{Path: "/", Host: "mlflow-fraud-1.mlflow.example.com", ServiceName: "mlflow-fraud-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateMlflowIngressObject() error = %v", err)
+	}
+
+	if len(ingress.Spec.Rules) != 2 {
+		t.Fatalf("CreateMlflowIngressObject() rules = %v, want server and host routed model rules", ingress.Spec.Rules)
+	}
+	if paths := ingress.Spec.Rules[0].HTTP.Paths; len(paths) != 1 || paths[0].Backend.Service.Name != "mlflow" {
+		t.Errorf("CreateMlflowIngressObject() server paths = %v, want only the server path", paths)
+	}
+	if rule := ingress.Spec.Rules[1]; rule.Host != "mlflow-fraud-1.mlflow.example.com" || rule.HTTP.Paths[0].Backend.Service.Name != "mlflow-fraud-1" {
+		t.Errorf("CreateMlflowIngressObject() model rule = %v, want host routed model", rule)
+	}
+	if want := []string{"mlflow.example.com", "mlflow-fraud-1.mlflow.example.com"}; !reflect.DeepEqual(ingress.Spec.TLS[0].Hosts, want) {
+		t.Errorf("CreateMlflowIngressObject() tls hosts = %v, want %v", ingress.Spec.TLS[0].Hosts, want)
+	}
+}
+
+func TestObjectManager_CreateMlflowModelIngressObject(t *testing.T) {
+	om := newIngressTestObjectManager(t)
+	config := &mlflowv1beta1.MLFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+		Spec: mlflowv1beta1.MLFlowSpec{
+			Ingress: &mlflowv1beta1.IngressSpec{
+				Host:          "mlflow.example.com",
+				TLSSecretName: "mlflow-tls",
+				Annotations:   map[string]string{"team": "ml"},
+			},
+		},
+	}
+
+	ingress, err := om.CreateMlflowModelIngressObject(config, []ModelRoute{
+		{Path: "/models/churn.v2/2", ServiceName: "mlflow-churn-2"},
+		{Path: "/", Host: "mlflow-fraud-1.mlflow.example.com", ServiceName: "mlflow-fraud-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateMlflowModelIngressObject() error = %v", err)
+	}
+
+	if ingress.Name != "mlflow-models" || len(ingress.GetOwnerReferences()) != 1 {
+		t.Errorf("CreateMlflowModelIngressObject() name = %s, owners = %v", ingress.Name, ingress.GetOwnerReferences())
+	}
+	expectedAnnotations := map[string]string{
+		"team":                                  "ml",
+		"nginx.ingress.kubernetes.io/use-regex": "true",
+		"nginx.ingress.kubernetes.io/rewrite-target": "/$2",
+	}
+	if !reflect.DeepEqual(ingress.Annotations, expectedAnnotations) {
+		t.Errorf("CreateMlflowModelIngressObject() annotations = %v, want %v", ingress.Annotations, expectedAnnotations)
+	}
+	paths := ingress.Spec.Rules[0].HTTP.Paths
+	if len(paths) != 1 || paths[0].Path != `/models/churn\.v2/2(/|$)(.*)` || paths[0].Backend.Service.Name != "mlflow-churn-2" {
+		t.Errorf("CreateMlflowModelIngressObject() paths = %v, want the path routed model", paths)
+	}
+	if want := []string{"mlflow.example.com"}; !reflect.DeepEqual(ingress.Spec.TLS[0].Hosts, want) {
+		t.Errorf("CreateMlflowModelIngressObject() tls hosts = %v, want %v", ingress.Spec.TLS[0].Hosts, want)
+	}
+	if _, ok := config.Spec.Ingress.Annotations[nginxRewriteTargetAnnotationKey]; ok {
+		t.Errorf("CreateMlflowModelIngressObject() modified the annotations of the spec")
+	}
+
+	ingress, err = om.CreateMlflowModelIngressObject(config, []ModelRoute{{Path: "/", Host: "mlflow-fraud-1.mlflow.example.com", ServiceName: "mlflow-fraud-1"}})
+	if err != nil || ingress != nil {
+		t.Errorf("CreateMlflowModelIngressObject() without path routed models = %v, %v, want nil", ingress, err)
+	}
+}
+
+func TestObjectManager_CreateMlflowHTTPRouteObject(t *testing.T) {
+	om := newIngressTestObjectManager(t)
+	config := &mlflowv1beta1.MLFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+		Spec: mlflowv1beta1.MLFlowSpec{
+			Ingress: &mlflowv1beta1.IngressSpec{
+				Kind:       mlflowv1beta1.IngressKindHTTPRoute,
+				ParentRefs: []mlflowv1beta1.GatewayParentReference{{Name: "gateway", Namespace: "infra"}},
+			},
+		},
+	}
+
+	httpRoute, err := om.CreateMlflowHTTPRouteObject(config, []ModelRoute{{Path: "/models/churn/2", ServiceName: "mlflow-churn-2"}})
+	if err != nil {
+		t.Fatalf("CreateMlflowHTTPRouteObject() error = %v", err)
+	}
+
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	if len(rules) != 2 {
+		t.Fatalf("CreateMlflowHTTPRouteObject() rules = %v, want 2 rules", rules)
+	}
+	backendName, _, _ := unstructured.NestedString(rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{}), "name")
+	if backendName != "mlflow-churn-2" {
+		t.Errorf("CreateMlflowHTTPRouteObject() first backend = %s, want mlflow-churn-2", backendName)
+	}
+	if httpRoute.GetKind() != HTTPRouteKind || len(httpRoute.GetOwnerReferences()) != 1 {
+		t.Errorf("CreateMlflowHTTPRouteObject() kind = %s, owners = %v", httpRoute.GetKind(), httpRoute.GetOwnerReferences())
+	}
+}
+
+func TestObjectManager_CreateMlflowHTTPRouteObjectCanary(t *testing.T) {
+	om := newIngressTestObjectManager(t)
+	config := &mlflowv1beta1.MLFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+		Spec: mlflowv1beta1.MLFlowSpec{
+			Ingress: &mlflowv1beta1.IngressSpec{
+				Kind:       mlflowv1beta1.IngressKindHTTPRoute,
+				ParentRefs: []mlflowv1beta1.GatewayParentReference{{Name: "gateway"}},
+			},
+		},
+	}
+	models := []mlflowv1beta1.ModelStatus{{
+		Name:           "fraud",
+		Alias:          "champion",
+		DeploymentName: "mlflow-fraud-champion",
+		Endpoint:       "http://mlflow-fraud-champion.ml.svc.cluster.local:5000",
+		Promotion: &mlflowv1beta1.ModelPromotionStatus{
+			Version:        "3",
+			DeploymentName: "mlflow-fraud-champion-v3",
+			Phase:          mlflowv1beta1.ModelPromotionCanary,
+			Weight:         10,
+		},
+	}}
+
+	routes := om.CreateModelRoutes(config, models)
+	expectedRoutes := []ModelRoute{{
+		Path:              "/models/fraud/champion",
+		ServiceName:       "mlflow-fraud-champion",
+		CanaryServiceName: "mlflow-fraud-champion-v3",
+		CanaryWeight:      10,
+	}}
+	if !reflect.DeepEqual(routes, expectedRoutes) {
+		t.Fatalf("CreateModelRoutes() = %v, want %v", routes, expectedRoutes)
+	}
+
+	httpRoute, err := om.CreateMlflowHTTPRouteObject(config, routes)
+	if err != nil {
+		t.Fatalf("CreateMlflowHTTPRouteObject() error = %v", err)
+	}
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	expectedBackendRefs := []interface{}{
+		map[string]interface{}{"name": "mlflow-fraud-champion", "port": int64(5000), "weight": int64(90)},
+		map[string]interface{}{"name": "mlflow-fraud-champion-v3", "port": int64(5000), "weight": int64(10)},
+	}
+	if got := rules[0].(map[string]interface{})["backendRefs"]; !reflect.DeepEqual(got, expectedBackendRefs) {
+		t.Errorf("CreateMlflowHTTPRouteObject() backendRefs = %v, want %v", got, expectedBackendRefs)
+	}
+
+	if weight, ok := HTTPRouteBackendWeight(httpRoute, "mlflow-fraud-champion-v3"); !ok || weight != 10 {
+		t.Errorf("HTTPRouteBackendWeight() = %d, %v, want 10, true", weight, ok)
+	}
+	if _, ok := HTTPRouteBackendWeight(httpRoute, "mlflow"); ok {
+		t.Errorf("HTTPRouteBackendWeight() of the unweighted server backend = true, want false")
+	}
+}
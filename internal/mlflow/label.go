@@ -0,0 +1,22 @@
+package mlflow
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ToLabelValue converts a registry name to a valid label value by replacing the unsupported characters and truncating it
+func ToLabelValue(value string) string {
+	labelValue := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, value)
+
+	if len(labelValue) > validation.LabelValueMaxLength {
+		labelValue = labelValue[:validation.LabelValueMaxLength]
+	}
+	return strings.Trim(labelValue, "-_.")
+}
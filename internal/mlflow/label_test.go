@@ -0,0 +1,27 @@
+package mlflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToLabelValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "valid", value: "Fraud_Model.v2", expected: "Fraud_Model.v2"},
+		{name: "unsupported characters", value: "fraud model/v2", expected: "fraud-model-v2"},
+		{name: "leading and trailing symbols", value: "_fraud model ", expected: "fraud-model"},
+		{name: "too long", value: strings.Repeat("a", 70), expected: strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToLabelValue(tt.value); result != tt.expected {
+				t.Errorf("ToLabelValue() = %s, want %s", result, tt.expected)
+			}
+		})
+	}
+}
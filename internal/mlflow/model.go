@@ -1,18 +1,74 @@
 package mlflow
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
 
 type Model struct {
+	// Tags of the model version
+	Tags    map[string]string
 	Name    string
 	Version string
+	Alias   string
+	// Stage of the model version in the registry
+	Stage string
+	// Aliases pointing to the model version
+	Aliases []string
+	// LastUpdatedTimestamp is the last time the model version was updated in the registry, in milliseconds
+	LastUpdatedTimestamp int64
+	// IsLatestInStage is true when the model version is the latest version of the model in its stage
+	IsLatestInStage bool
 }
 
 func (m Model) ToLowerName() string {
 	return strings.ToLower(m.Name)
 }
 
+// GenerateDeploymentName returns the deployment name of the model, which is stable across versions when the model is served by alias
 func (m Model) GenerateDeploymentName(prefix string) string {
+	if m.Alias != "" {
+		return prefix + "-" + m.ToLowerName() + "-" + strings.ToLower(m.Alias)
+	}
 	return prefix + "-" + m.ToLowerName() + "-" + m.Version
 }
 
+// GenerateVersionDeploymentName returns the deployment name of the model version promoted behind the Service of its alias
+func (m Model) GenerateVersionDeploymentName(prefix string) string {
+	return m.GenerateDeploymentName(prefix) + "-v" + strings.ToLower(m.Version)
+}
+
+// URI returns the models:/ URI of the model, resolving by alias when the alias is set
+func (m Model) URI() string {
+	if m.Alias != "" {
+		return fmt.Sprintf("models:/%s@%s", m.Name, m.Alias)
+	}
+	return fmt.Sprintf("models:/%s/%s", m.Name, m.Version)
+}
+
+// IsArchived reports whether the model version is in the Archived stage
+func (m Model) IsArchived() bool {
+	return strings.EqualFold(m.Stage, string(mlflowv1beta1.ModelStageArchived))
+}
+
 type Models []Model
+
+// ServedByAlias returns the models to deploy, serving each aliased version once per alias instead of by version
+func (m Models) ServedByAlias() Models {
+	served := make(Models, 0, len(m))
+	for _, model := range m {
+		if len(model.Aliases) == 0 {
+			served = append(served, model)
+			continue
+		}
+
+		for _, alias := range model.Aliases {
+			aliasModel := model
+			aliasModel.Alias = alias
+			served = append(served, aliasModel)
+		}
+	}
+	return served
+}
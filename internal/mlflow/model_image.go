@@ -0,0 +1,30 @@
+package mlflow
+
+import (
+	"fmt"
+	"strings"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+// ResolveModelImage returns the image overriding the model image of the MLFlow instance,
+// rejecting images which are not in its model image allowlist
+func (om *ObjectManager) ResolveModelImage(config *mlflowv1beta1.MLFlow, image string) (string, error) {
+	if image == "" || image == config.Spec.ModelImage {
+		return config.Spec.ModelImage, nil
+	}
+
+	for _, allowed := range config.Spec.ModelImageAllowlist {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(image, prefix) {
+				return image, nil
+			}
+			continue
+		}
+		if image == allowed {
+			return image, nil
+		}
+	}
+
+	return config.Spec.ModelImage, fmt.Errorf("image %s is not in the model image allowlist", image)
+}
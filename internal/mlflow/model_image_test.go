@@ -0,0 +1,41 @@
+package mlflow
+
+import (
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+func TestObjectManager_ResolveModelImage(t *testing.T) {
+	om := &ObjectManager{}
+	config := &mlflowv1beta1.MLFlow{
+		Spec: mlflowv1beta1.MLFlowSpec{
+			ModelImage:          "mlflow:2.12.1",
+			ModelImageAllowlist: []string{"mlflow-gpu:2.12.1", "registry.example.com/ml/*"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		image    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "default", expected: "mlflow:2.12.1"},
+		{name: "exact", image: "mlflow-gpu:2.12.1", expected: "mlflow-gpu:2.12.1"},
+		{name: "prefix", image: "registry.example.com/ml/serving:1.0", expected: "registry.example.com/ml/serving:1.0"},
+		{name: "not allowed", image: "evil.example.com/miner:latest", expected: "mlflow:2.12.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := om.ResolveModelImage(config, tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveModelImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ResolveModelImage() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package mlflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+// ModelSelector filters the registered model versions served by the operator
+type ModelSelector struct {
+	selection *mlflowv1beta1.ModelSelectionSpec
+	include   []*regexp.Regexp
+	exclude   []*regexp.Regexp
+}
+
+// NewModelSelector compiles the name patterns of the selection, a nil selection selects every model version
+func NewModelSelector(selection *mlflowv1beta1.ModelSelectionSpec) (*ModelSelector, error) {
+	selector := &ModelSelector{selection: selection}
+	if selection == nil {
+		return selector, nil
+	}
+
+	var err error
+	if selector.include, err = compilePatterns(selection.Include); err != nil {
+		return nil, fmt.Errorf("invalid include pattern: %w", err)
+	}
+	if selector.exclude, err = compilePatterns(selection.Exclude); err != nil {
+		return nil, fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+
+	return selector, nil
+}
+
+// Select returns the selected model versions, keeping the order of the given models
+func (s *ModelSelector) Select(models Models) Models {
+	var matched Models
+	for _, model := range models {
+		if s.matches(model) {
+			matched = append(matched, model)
+		}
+	}
+
+	if s.selection == nil || s.selection.LatestVersions == nil {
+		return matched
+	}
+	return latestVersions(matched, int(*s.selection.LatestVersions))
+}
+
+func (s *ModelSelector) matches(model Model) bool {
+	if s.selection == nil {
+		return true
+	}
+	return s.matchesName(model.Name) && s.matchesStage(model) && s.matchesTag(model.Tags)
+}
+
+func (s *ModelSelector) matchesName(name string) bool {
+	for _, pattern := range s.exclude {
+		if pattern.MatchString(name) {
+			return false
+		}
+	}
+
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, pattern := range s.include {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ModelSelector) matchesStage(model Model) bool {
+	if len(s.selection.Stages) == 0 {
+		return !model.IsArchived()
+	}
+	if !model.IsLatestInStage {
+		return false
+	}
+	for _, selectedStage := range s.selection.Stages {
+		if strings.EqualFold(string(selectedStage), model.Stage) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ModelSelector) matchesTag(tags map[string]string) bool {
+	if s.selection.Tag == nil {
+		return true
+	}
+	value, ok := tags[s.selection.Tag.Key]
+	return ok && (s.selection.Tag.Value == "" || s.selection.Tag.Value == value)
+}
+
+// latestVersions keeps the highest n versions of each model
+func latestVersions(models Models, n int) Models {
+	versionsByModel := make(map[string][]string)
+	for _, model := range models {
+		versionsByModel[model.Name] = append(versionsByModel[model.Name], model.Version)
+	}
+
+	selectedVersions := make(map[string]map[string]struct{}, len(versionsByModel))
+	for name, versions := range versionsByModel {
+		sort.Slice(versions, func(i, j int) bool {
+			return isNewerVersion(versions[i], versions[j])
+		})
+		if len(versions) > n {
+			versions = versions[:n]
+		}
+
+		selectedVersions[name] = make(map[string]struct{}, len(versions))
+		for _, version := range versions {
+			selectedVersions[name][version] = struct{}{}
+		}
+	}
+
+	var selected Models
+	for _, model := range models {
+		if _, ok := selectedVersions[model.Name][model.Version]; ok {
+			selected = append(selected, model)
+		}
+	}
+	return selected
+}
+
+// isNewerVersion compares model versions numerically, as the registry assigns them incrementally
+func isNewerVersion(version, other string) bool {
+	versionNumber, versionErr := strconv.Atoi(version)
+	otherNumber, otherErr := strconv.Atoi(other)
+	if versionErr != nil || otherErr != nil {
+		return version > other
+	}
+	return versionNumber > otherNumber
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
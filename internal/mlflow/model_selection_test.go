@@ -0,0 +1,83 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+)
+
+func TestModelSelector_Select(t *testing.T) {
+	latestVersions := int32(2)
+	models := Models{
+		{Name: "fraud", Version: "1", Stage: "Archived", IsLatestInStage: true},
+		{Name: "fraud", Version: "2", Stage: "Production", Tags: map[string]string{"serve": "true"}},
+		{Name: "fraud", Version: "10", Stage: "Production", IsLatestInStage: true, Tags: map[string]string{"serve": "false"}},
+		{Name: "churn", Version: "1", Stage: "Staging", IsLatestInStage: true},
+		{Name: "churn-experimental", Version: "1", Tags: map[string]string{"serve": "true"}},
+	}
+
+	tests := []struct {
+		selection *mlflowv1beta1.ModelSelectionSpec
+		name      string
+		expected  Models
+	}{
+		{
+			name:     "no selection",
+			expected: models,
+		},
+		{
+			name:      "selection without stages skips archived versions",
+			selection: &mlflowv1beta1.ModelSelectionSpec{},
+			expected:  Models{models[1], models[2], models[3], models[4]},
+		},
+		{
+			name:      "archived stage",
+			selection: &mlflowv1beta1.ModelSelectionSpec{Stages: []mlflowv1beta1.ModelStage{mlflowv1beta1.ModelStageArchived}},
+			expected:  Models{models[0]},
+		},
+		{
+			name:      "latest versions",
+			selection: &mlflowv1beta1.ModelSelectionSpec{LatestVersions: &latestVersions},
+			expected:  Models{models[1], models[2], models[3], models[4]},
+		},
+		{
+			name:      "stages",
+			selection: &mlflowv1beta1.ModelSelectionSpec{Stages: []mlflowv1beta1.ModelStage{mlflowv1beta1.ModelStageProduction, mlflowv1beta1.ModelStageStaging}},
+			expected:  Models{models[2], models[3]},
+		},
+		{
+			name:      "tag key",
+			selection: &mlflowv1beta1.ModelSelectionSpec{Tag: &mlflowv1beta1.ModelTagSelector{Key: "serve"}},
+			expected:  Models{models[1], models[2], models[4]},
+		},
+		{
+			name:      "tag value",
+			selection: &mlflowv1beta1.ModelSelectionSpec{Tag: &mlflowv1beta1.ModelTagSelector{Key: "serve", Value: "true"}},
+			expected:  Models{models[1], models[4]},
+		},
+		{
+			name:      "include and exclude",
+			selection: &mlflowv1beta1.ModelSelectionSpec{Include: []string{"^churn"}, Exclude: []string{"experimental$"}},
+			expected:  Models{models[3]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := NewModelSelector(tt.selection)
+			if err != nil {
+				t.Fatalf("NewModelSelector() error = %v", err)
+			}
+			if got := selector.Select(models); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Select() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewModelSelector_InvalidPattern(t *testing.T) {
+	if _, err := NewModelSelector(&mlflowv1beta1.ModelSelectionSpec{Include: []string{"("}}); err == nil {
+		t.Errorf("Expected error for invalid include pattern")
+	}
+}
@@ -1,6 +1,7 @@
 package mlflow
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -24,3 +25,58 @@ func TestModelGenerateDeploymentName(t *testing.T) {
 		t.Errorf("Expected %s, but got %s", expected, result)
 	}
 }
+
+func TestModelURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		model    Model
+	}{
+		{name: "version", model: Model{Name: "MyModel", Version: "3"}, expected: "models:/MyModel/3"},
+		{name: "alias", model: Model{Name: "MyModel", Version: "3", Alias: "champion"}, expected: "models:/MyModel@champion"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.model.URI(); result != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestModelGenerateDeploymentNameWithAlias(t *testing.T) {
+	model := Model{Name: "Fraud", Version: "3", Alias: "Champion"}
+	expected := "mlflow-fraud-champion"
+	result := model.GenerateDeploymentName("mlflow")
+
+	if result != expected {
+		t.Errorf("Expected %s, but got %s", expected, result)
+	}
+}
+
+func TestModelGenerateVersionDeploymentName(t *testing.T) {
+	model := Model{Name: "Fraud", Version: "3", Alias: "Champion"}
+	expected := "mlflow-fraud-champion-v3"
+	result := model.GenerateVersionDeploymentName("mlflow")
+
+	if result != expected {
+		t.Errorf("Expected %s, but got %s", expected, result)
+	}
+}
+
+func TestModelsServedByAlias(t *testing.T) {
+	models := Models{
+		{Name: "fraud", Version: "1"},
+		{Name: "fraud", Version: "2", Aliases: []string{"champion", "challenger"}},
+	}
+	expected := Models{
+		{Name: "fraud", Version: "1"},
+		{Name: "fraud", Version: "2", Alias: "champion", Aliases: []string{"champion", "challenger"}},
+		{Name: "fraud", Version: "2", Alias: "challenger", Aliases: []string{"champion", "challenger"}},
+	}
+
+	if result := models.ServedByAlias(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
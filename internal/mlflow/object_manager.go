@@ -2,6 +2,7 @@ package mlflow
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
@@ -12,11 +13,32 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	appLabelKey = "app"
+
+	defaultModelPort  = 5000
+	defaultServerPort = 5000
+	modelPortName     = "http"
+	mlserverGRPCPort  = 8081
+	grpcPortName      = "grpc"
+
+	// ModelVersionAnnotationKey holds the served model version on the model pods, rolling them out when an alias moves
+	ModelVersionAnnotationKey = "mlflow.trendyol.com/model-version"
+
+	// MLFlowLabelKey holds the name of the MLFlow instance on its model deployments
+	MLFlowLabelKey = "mlflow.trendyol.com/mlflow"
+	// ModelNameLabelKey holds the registered model name on the model deployments
+	ModelNameLabelKey = "mlflow.trendyol.com/model-name"
+	// ModelVersionLabelKey holds the served model version on the model deployments
+	ModelVersionLabelKey = "mlflow.trendyol.com/model-version"
+	// ModelAliasLabelKey holds the served model alias on the model deployments
+	ModelAliasLabelKey = "mlflow.trendyol.com/model-alias"
+	// ModelServiceLabelKey holds the Service of the alias on the pods of the promoted model versions
+	ModelServiceLabelKey = "mlflow.trendyol.com/model-service"
 )
 
 type ObjectManager struct {
@@ -24,18 +46,54 @@ type ObjectManager struct {
 	Debug  bool
 }
 
+// CreateMlflowModelDeploymentObject creates the Deployment serving the model, owned by config.Owner or the MLFlow instance if it is not set
 func (om *ObjectManager) CreateMlflowModelDeploymentObject(config ModelDeploymentObjectConfig) (*appsv1.Deployment, error) {
 	var replicas int32 = 1
+	if config.Replicas != nil {
+		replicas = *config.Replicas
+	}
+
+	depName := config.DeploymentName
+	if depName == "" {
+		depName = config.Model.GenerateDeploymentName(config.MlFlowServerConfig.Name)
+	}
+
+	var resources corev1.ResourceRequirements
+	if config.Resources != nil {
+		resources = *config.Resources
+	}
+
+	var owner client.Object = config.MlFlowServerConfig
+	if config.Owner != nil {
+		owner = config.Owner
+	}
 
-	depName := config.Model.GenerateDeploymentName(config.MlFlowServerConfig.Name)
+	port := modelPort(config)
+	runtime := modelRuntime(config)
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "MLFLOW_TRACKING_URI",
+			Value: config.MlFlowTrackingURI,
+		},
+	}
+	env = append(env, config.Env...)
+
+	var podAnnotations map[string]string
+	if config.Model.Version != "" {
+		podAnnotations = map[string]string{ModelVersionAnnotationKey: config.Model.Version}
+	}
+
+	podLabels := map[string]string{appLabelKey: depName}
+	if config.ServiceName != "" {
+		podLabels[ModelServiceLabelKey] = config.ServiceName
+	}
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      depName,
 			Namespace: config.Namespace,
-			Labels: map[string]string{
-				appLabelKey: depName,
-			},
+			Labels:    om.createModelDeploymentLabels(depName, config),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
@@ -45,40 +103,21 @@ func (om *ObjectManager) CreateMlflowModelDeploymentObject(config ModelDeploymen
 				},
 			},
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{appLabelKey: depName}},
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
 							Name:            depName,
 							Image:           config.MlFlowModelImage,
 							ImagePullPolicy: corev1.PullIfNotPresent,
-							Env: []corev1.EnvVar{
-								{
-									Name:  "MLFLOW_TRACKING_URI",
-									Value: config.MlFlowTrackingURI,
-								},
-							},
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    config.CPULimit,
-									corev1.ResourceMemory: config.MemoryLimit,
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    config.CPURequest,
-									corev1.ResourceMemory: config.MemoryRequest,
-								},
-							},
-							Command: []string{"mlflow"},
-							Args: []string{
-								"models",
-								"serve",
-								"-m",
-								fmt.Sprintf("models:/%s/%s", config.Model.Name, config.Model.Version),
-								"--host",
-								"0.0.0.0",
-								"--env-manager",
-								"conda",
-							},
+							Env:             env,
+							Resources:       resources,
+							Ports:           modelContainerPorts(runtime, port),
+							Command:         []string{"mlflow"},
+							Args:            modelServeArgs(config, runtime, port),
 						},
 					},
 				},
@@ -86,14 +125,195 @@ func (om *ObjectManager) CreateMlflowModelDeploymentObject(config ModelDeploymen
 		},
 	}
 
-	if err := controllerutil.SetControllerReference(config.MlFlowServerConfig, deployment, om.Scheme); err != nil {
+	setModelProbes(&deployment.Spec.Template.Spec.Containers[0], config, runtime)
+
+	if modelDefaults := config.MlFlowServerConfig.Spec.ModelDefaults; modelDefaults != nil {
+		om.ApplyPodTemplate(&deployment.Spec.Template, modelDefaults.PodTemplate)
+	}
+	if len(config.NodeSelector) > 0 {
+		deployment.Spec.Template.Spec.NodeSelector = mergeStringMaps(deployment.Spec.Template.Spec.NodeSelector, config.NodeSelector)
+	}
+
+	if err := controllerutil.SetControllerReference(owner, deployment, om.Scheme); err != nil {
 		return nil, err
 	}
 
 	return deployment, nil
 }
 
+// CreateMlflowModelServiceObject creates the ClusterIP Service exposing the model deployment,
+// owned by config.Owner or the MLFlow instance if it is not set
+func (om *ObjectManager) CreateMlflowModelServiceObject(config ModelDeploymentObjectConfig) (*corev1.Service, error) {
+	depName := config.DeploymentName
+	if depName == "" {
+		depName = config.Model.GenerateDeploymentName(config.MlFlowServerConfig.Name)
+	}
+	servingDepName := depName
+	if config.ServingDeploymentName != "" {
+		servingDepName = config.ServingDeploymentName
+	}
+
+	var owner client.Object = config.MlFlowServerConfig
+	if config.Owner != nil {
+		owner = config.Owner
+	}
+
+	var ports []corev1.ServicePort
+	for _, containerPort := range modelContainerPorts(modelRuntime(config), modelPort(config)) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       containerPort.Name,
+			Port:       containerPort.ContainerPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString(containerPort.Name),
+		})
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      depName,
+			Namespace: config.Namespace,
+			Labels:    om.createModelDeploymentLabels(depName, config),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				appLabelKey: servingDepName,
+			},
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: ports,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(owner, service, om.Scheme); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// ServiceURL returns the in-cluster URL of the first port of the service
+func ServiceURL(service *corev1.Service) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", service.Name, service.Namespace, service.Spec.Ports[0].Port)
+}
+
+// ServingDeploymentName returns the name of the model deployment selected by the service
+func ServingDeploymentName(service *corev1.Service) string {
+	return service.Spec.Selector[appLabelKey]
+}
+
+func modelPort(config ModelDeploymentObjectConfig) int32 {
+	if config.Port != 0 {
+		return config.Port
+	}
+	return defaultModelPort
+}
+
+// modelEnvManager returns the env manager of the model, falling back to the model defaults of the MLFlow instance and conda
+func modelEnvManager(config ModelDeploymentObjectConfig) mlflowv1beta1.EnvManager {
+	if config.EnvManager != "" {
+		return config.EnvManager
+	}
+	if modelDefaults := config.MlFlowServerConfig.Spec.ModelDefaults; modelDefaults != nil && modelDefaults.EnvManager != "" {
+		return modelDefaults.EnvManager
+	}
+	return mlflowv1beta1.EnvManagerConda
+}
+
+// modelRuntime returns the serving runtime of the model, falling back to the model defaults of the MLFlow instance
+// and the MLflow scoring server
+func modelRuntime(config ModelDeploymentObjectConfig) mlflowv1beta1.ServingRuntime {
+	if config.Runtime != "" {
+		return config.Runtime
+	}
+	if modelDefaults := config.MlFlowServerConfig.Spec.ModelDefaults; modelDefaults != nil && modelDefaults.Runtime != "" {
+		return modelDefaults.Runtime
+	}
+	return mlflowv1beta1.ServingRuntimeMLflow
+}
+
+func modelServeArgs(config ModelDeploymentObjectConfig, runtime mlflowv1beta1.ServingRuntime, port int32) []string {
+	args := []string{
+		"models",
+		"serve",
+		"-m",
+		config.Model.URI(),
+		"--host",
+		"0.0.0.0",
+		"--port",
+		strconv.Itoa(int(port)),
+		"--env-manager",
+		string(modelEnvManager(config)),
+	}
+	if config.Workers != nil {
+		args = append(args, "--workers", strconv.Itoa(int(*config.Workers)))
+	}
+
+	// MLServer has no request timeout, the timeout only applies to the MLflow scoring server
+	if runtime == mlflowv1beta1.ServingRuntimeMLServer {
+		return append(args, "--enable-mlserver")
+	}
+	if config.Timeout > 0 {
+		args = append(args, "--timeout", strconv.Itoa(int(config.Timeout.Seconds())))
+	}
+	return args
+}
+
+// modelContainerPorts returns the ports of the model container, the HTTP port first
+func modelContainerPorts(runtime mlflowv1beta1.ServingRuntime, port int32) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{
+		{
+			Name:          modelPortName,
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	if runtime == mlflowv1beta1.ServingRuntimeMLServer {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          grpcPortName,
+			ContainerPort: mlserverGRPCPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	return ports
+}
+
+func (om *ObjectManager) createModelDeploymentLabels(depName string, config ModelDeploymentObjectConfig) map[string]string {
+	labels := map[string]string{
+		appLabelKey:       depName,
+		MLFlowLabelKey:    ToLabelValue(config.MlFlowServerConfig.Name),
+		ModelNameLabelKey: ToLabelValue(config.Model.Name),
+	}
+	if config.Model.Version != "" {
+		labels[ModelVersionLabelKey] = ToLabelValue(config.Model.Version)
+	}
+	if config.Model.Alias != "" {
+		labels[ModelAliasLabelKey] = ToLabelValue(config.Model.Alias)
+	}
+	return labels
+}
+
 func (om *ObjectManager) CreateMlflowServiceObject(name string, namespace string, config *mlflowv1beta1.MLFlow) (*corev1.Service, error) {
+	serviceSpec := config.Spec.Service
+	if serviceSpec == nil {
+		serviceSpec = &mlflowv1beta1.ServiceSpec{}
+	}
+
+	serviceType := serviceSpec.Type
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeClusterIP
+	}
+
+	servicePort := corev1.ServicePort{
+		Port:     ServerServicePort(config),
+		Protocol: corev1.ProtocolTCP,
+		TargetPort: intstr.IntOrString{
+			Type:   intstr.Int,
+			IntVal: defaultServerPort,
+		},
+	}
+	if serviceType != corev1.ServiceTypeClusterIP {
+		servicePort.NodePort = serviceSpec.NodePort
+	}
+
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -101,25 +321,19 @@ func (om *ObjectManager) CreateMlflowServiceObject(name string, namespace string
 			Labels: map[string]string{
 				"app": name,
 			},
+			Annotations: serviceSpec.Annotations,
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: map[string]string{
 				"app": name,
 			},
-			Type: corev1.ServiceTypeNodePort,
-			Ports: []corev1.ServicePort{
-				{
-					Port:     5000,
-					Protocol: corev1.ProtocolTCP,
-					TargetPort: intstr.IntOrString{
-						Type:   intstr.Int,
-						IntVal: 5000,
-					},
-					NodePort: 30099,
-				},
-			},
+			Type:  serviceType,
+			Ports: []corev1.ServicePort{servicePort},
 		},
 	}
+	if serviceType == corev1.ServiceTypeLoadBalancer {
+		service.Spec.LoadBalancerSourceRanges = serviceSpec.LoadBalancerSourceRanges
+	}
 
 	if err := controllerutil.SetControllerReference(config, service, om.Scheme); err != nil {
 		return nil, err
@@ -128,6 +342,19 @@ func (om *ObjectManager) CreateMlflowServiceObject(name string, namespace string
 	return service, nil
 }
 
+// ServerServicePort returns the port the Service of the MLFlow server exposes
+func ServerServicePort(config *mlflowv1beta1.MLFlow) int32 {
+	if config.Spec.Service != nil && config.Spec.Service.Port != 0 {
+		return config.Spec.Service.Port
+	}
+	return defaultServerPort
+}
+
+// TrackingURI returns the in-cluster URL of the MLFlow server
+func TrackingURI(config *mlflowv1beta1.MLFlow) string {
+	return fmt.Sprintf("http://%s:%d", config.Name, ServerServicePort(config))
+}
+
 func (om *ObjectManager) CreateVolumeObject(volumes []string) []corev1.Volume {
 	volumeList := make([]corev1.Volume, 0, len(volumes))
 
@@ -158,58 +385,45 @@ func (om *ObjectManager) CreateVolumeMountObject(volumes []string) []corev1.Volu
 	return volumeMountList
 }
 
-func (om *ObjectManager) CreateMlflowDeploymentObject(name string, namespace string, config *mlflowv1beta1.MLFlow) (*appsv1.Deployment, error) {
+func (om *ObjectManager) CreateMlflowDeploymentObject(
+	name string,
+	namespace string,
+	config *mlflowv1beta1.MLFlow,
+	configMap *corev1.ConfigMap,
+) (*appsv1.Deployment, error) {
 	args := []string{
 		"server",
 		"--serve-artifacts",
 		"--host",
 		"0.0.0.0",
-		"--artifacts-destination",
-		"s3://mlflow",
-		"--backend-store-uri",
-		"postgresql://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)",
 	}
 
-	// TODO add this values to config map
-	env := []corev1.EnvVar{
-		{
-			Name:  "AWS_ACCESS_KEY_ID",
-			Value: "cAUnfrfQ2uGfeB9Y",
-		},
-		{
-			Name:  "AWS_SECRET_ACCESS_KEY",
-			Value: "8vjDVvwlDS07m4dOWw4vwUh9ReFFdCf1",
-		},
-		{
-			Name:  "MLFLOW_S3_ENDPOINT_URL",
-			Value: "https://minio.minio.svc.cluster.local",
-		},
-		{
-			Name:  "MLFLOW_S3_IGNORE_TLS",
-			Value: "true",
-		},
-		{
-			Name:  "DB_USER",
-			Value: "admin",
-		},
-		{
-			Name:  "DB_PASSWORD",
-			Value: "123456",
-		},
-		{
-			Name:  "DB_HOST",
-			Value: "postgres.postgres",
-		},
-		{
-			Name:  "DB_PORT",
-			Value: "5432",
-		},
-		{
-			Name:  "DB_NAME",
-			Value: "mlflow",
-		},
+	var env []corev1.EnvVar
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+
+	if config.Spec.ArtifactStore != nil {
+		artifactStoreConfig, err := om.CreateArtifactStoreConfig(config.Spec.ArtifactStore)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--artifacts-destination", artifactStoreConfig.Destination)
+		env = append(env, artifactStoreConfig.Env...)
+		volumes = append(volumes, artifactStoreConfig.Volumes...)
+		volumeMounts = append(volumeMounts, artifactStoreConfig.VolumeMounts...)
+	}
+
+	if config.Spec.BackendStore != nil {
+		backendStoreURI, err := om.CreateBackendStoreURI(config.Spec.BackendStore)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--backend-store-uri", backendStoreURI)
+		env = append(env, om.CreateBackendStoreEnv(config.Spec.BackendStore)...)
 	}
 
+	args = append(args, om.CreateServerArgsFromConfigMap(configMap)...)
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -226,7 +440,12 @@ func (om *ObjectManager) CreateMlflowDeploymentObject(name string, namespace str
 				},
 			},
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": name},
+					Annotations: map[string]string{
+						ConfigHashAnnotationKey: om.ConfigMapHash(configMap),
+					},
+				},
 				Spec: corev1.PodSpec{
 					ResourceClaims: []corev1.PodResourceClaim{},
 					Containers: []corev1.Container{
@@ -235,15 +454,30 @@ func (om *ObjectManager) CreateMlflowDeploymentObject(name string, namespace str
 							Image:           config.Spec.Image,
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							Env:             env,
-							Command:         []string{"mlflow"},
-							Args:            args,
+							EnvFrom: []corev1.EnvFromSource{
+								{
+									ConfigMapRef: &corev1.ConfigMapEnvSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+									},
+								},
+							},
+							Command:      []string{"mlflow"},
+							Args:         args,
+							VolumeMounts: volumeMounts,
 						},
 					},
+					Volumes: volumes,
 				},
 			},
 		},
 	}
 
+	setServerProbes(&deployment.Spec.Template.Spec.Containers[0], config)
+
+	if config.Spec.Server != nil {
+		om.ApplyPodTemplate(&deployment.Spec.Template, config.Spec.Server.PodTemplate)
+	}
+
 	if err := controllerutil.SetControllerReference(config, deployment, om.Scheme); err != nil {
 		return nil, err
 	}
@@ -314,7 +548,7 @@ func (om *ObjectManager) CreateMlflowWineQualityJobObject(name string, namespace
 							Env: []corev1.EnvVar{
 								{
 									Name:  "TRACKING_URL",
-									Value: fmt.Sprintf("http://%s:5000", config.Name),
+									Value: TrackingURI(config),
 								},
 							},
 						},
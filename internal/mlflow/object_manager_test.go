@@ -0,0 +1,119 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObjectManager_CreateMlflowModelDeploymentObject(t *testing.T) {
+	om := newIngressTestObjectManager(t)
+
+	tests := []struct {
+		modelDefaults *mlflowv1beta1.ModelDefaultsSpec
+		name          string
+		runtime       mlflowv1beta1.ServingRuntime
+		envManager    mlflowv1beta1.EnvManager
+		expectedProbe string
+		expectedArgs  []string
+		expectedPorts []string
+	}{
+		{
+			name:          "mlflow runtime with conda by default",
+			expectedArgs:  []string{"models", "serve", "-m", "models:/churn/2", "--host", "0.0.0.0", "--port", "5000", "--env-manager", "conda"},
+			expectedPorts: []string{"http"},
+			expectedProbe: "/ping",
+		},
+		{
+			name: "runtime and env manager from model defaults",
+			modelDefaults: &mlflowv1beta1.ModelDefaultsSpec{
+				EnvManager: mlflowv1beta1.EnvManagerLocal,
+				Runtime:    mlflowv1beta1.ServingRuntimeMLServer,
+			},
+			expectedArgs:  []string{"models", "serve", "-m", "models:/churn/2", "--host", "0.0.0.0", "--port", "5000", "--env-manager", "local", "--enable-mlserver"},
+			expectedPorts: []string{"http", "grpc"},
+			expectedProbe: "/v2/health/ready",
+		},
+		{
+			name: "runtime and env manager from tags",
+			modelDefaults: &mlflowv1beta1.ModelDefaultsSpec{
+				EnvManager: mlflowv1beta1.EnvManagerLocal,
+				Runtime:    mlflowv1beta1.ServingRuntimeMLServer,
+			},
+			runtime:       mlflowv1beta1.ServingRuntimeMLflow,
+			envManager:    mlflowv1beta1.EnvManagerVirtualenv,
+			expectedArgs:  []string{"models", "serve", "-m", "models:/churn/2", "--host", "0.0.0.0", "--port", "5000", "--env-manager", "virtualenv"},
+			expectedPorts: []string{"http"},
+			expectedProbe: "/ping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ModelDeploymentObjectConfig{
+				MlFlowServerConfig: &mlflowv1beta1.MLFlow{
+					ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+					Spec:       mlflowv1beta1.MLFlowSpec{ModelDefaults: tt.modelDefaults},
+				},
+				Namespace:  "ml",
+				Model:      Model{Name: "churn", Version: "2"},
+				EnvManager: tt.envManager,
+				Runtime:    tt.runtime,
+			}
+
+			deployment, err := om.CreateMlflowModelDeploymentObject(config)
+			if err != nil {
+				t.Fatalf("CreateMlflowModelDeploymentObject() error = %v", err)
+			}
+			container := deployment.Spec.Template.Spec.Containers[0]
+			if !reflect.DeepEqual(container.Args, tt.expectedArgs) {
+				t.Errorf("CreateMlflowModelDeploymentObject() args = %v, want %v", container.Args, tt.expectedArgs)
+			}
+			var ports []string
+			for _, port := range container.Ports {
+				ports = append(ports, port.Name)
+			}
+			if !reflect.DeepEqual(ports, tt.expectedPorts) {
+				t.Errorf("CreateMlflowModelDeploymentObject() ports = %v, want %v", ports, tt.expectedPorts)
+			}
+			if container.ReadinessProbe.HTTPGet.Path != tt.expectedProbe {
+				t.Errorf("CreateMlflowModelDeploymentObject() readiness path = %s, want %s", container.ReadinessProbe.HTTPGet.Path, tt.expectedProbe)
+			}
+		})
+	}
+}
+
+func TestObjectManager_CreateMlflowModelServiceObjectSelector(t *testing.T) {
+	om := newIngressTestObjectManager(t)
+
+	tests := []struct {
+		name                  string
+		servingDeploymentName string
+		expected              string
+	}{
+		{name: "model deployment", expected: "mlflow-fraud-champion"},
+		{name: "promoted version deployment", servingDeploymentName: "mlflow-fraud-champion-v3", expected: "mlflow-fraud-champion-v3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := om.CreateMlflowModelServiceObject(ModelDeploymentObjectConfig{
+				MlFlowServerConfig:    &mlflowv1beta1.MLFlow{ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"}},
+				Namespace:             "ml",
+				Model:                 Model{Name: "fraud", Version: "3", Alias: "champion"},
+				ServingDeploymentName: tt.servingDeploymentName,
+			})
+			if err != nil {
+				t.Fatalf("CreateMlflowModelServiceObject() error = %v", err)
+			}
+			if service.Name != "mlflow-fraud-champion" {
+				t.Errorf("CreateMlflowModelServiceObject() name = %v, want mlflow-fraud-champion", service.Name)
+			}
+			if got := service.Spec.Selector[appLabelKey]; got != tt.expected {
+				t.Errorf("CreateMlflowModelServiceObject() selector = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
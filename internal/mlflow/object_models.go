@@ -1,19 +1,36 @@
 package mlflow
 
 import (
+	"time"
+
 	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
-	"k8s.io/apimachinery/pkg/api/resource"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ModelDeploymentObjectConfig struct {
-	Name               string
-	Namespace          string
 	MlFlowServerConfig *mlflowv1beta1.MLFlow
-	Model              Model
-	CPURequest         resource.Quantity
-	CPULimit           resource.Quantity
-	MemoryRequest      resource.Quantity
-	MemoryLimit        resource.Quantity
-	MlFlowTrackingURI  string
-	MlFlowModelImage   string
+	Owner              client.Object
+	Resources          *corev1.ResourceRequirements
+	Probes             *mlflowv1beta1.ProbesSpec
+	NodeSelector       map[string]string
+	Replicas           *int32
+	Workers            *int32
+	// CanaryWeight is the first traffic weight of the model version set by its tag while it is promoted
+	CanaryWeight   *int32
+	Env            []corev1.EnvVar
+	Name           string
+	Namespace      string
+	DeploymentName string
+	// ServingDeploymentName is the deployment selected by the model Service, defaults to the deployment name
+	ServingDeploymentName string
+	// ServiceName is the Service of the alias the pods of a promoted version deployment are shared behind during a canary
+	ServiceName       string
+	MlFlowTrackingURI string
+	MlFlowModelImage  string
+	EnvManager        mlflowv1beta1.EnvManager
+	Runtime           mlflowv1beta1.ServingRuntime
+	Model             Model
+	Timeout           time.Duration
+	Port              int32
 }
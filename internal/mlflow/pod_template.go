@@ -0,0 +1,77 @@
+package mlflow
+
+import (
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyPodTemplate merges the pod template overrides into the generated pod template,
+// keeping the generated labels and annotations on conflicts
+func (om *ObjectManager) ApplyPodTemplate(template *corev1.PodTemplateSpec, podTemplate *mlflowv1beta1.PodTemplateSpec) {
+	if podTemplate == nil {
+		return
+	}
+
+	template.Labels = mergeMissing(template.Labels, podTemplate.Labels)
+	template.Annotations = mergeMissing(template.Annotations, podTemplate.Annotations)
+
+	podSpec := &template.Spec
+	if len(podTemplate.NodeSelector) > 0 {
+		podSpec.NodeSelector = podTemplate.NodeSelector
+	}
+	if podTemplate.Affinity != nil {
+		podSpec.Affinity = podTemplate.Affinity
+	}
+	if podTemplate.SecurityContext != nil {
+		podSpec.SecurityContext = podTemplate.SecurityContext
+	}
+	if len(podTemplate.Tolerations) > 0 {
+		podSpec.Tolerations = podTemplate.Tolerations
+	}
+	if len(podTemplate.TopologySpreadConstraints) > 0 {
+		podSpec.TopologySpreadConstraints = podTemplate.TopologySpreadConstraints
+	}
+	if len(podTemplate.ImagePullSecrets) > 0 {
+		podSpec.ImagePullSecrets = podTemplate.ImagePullSecrets
+	}
+	if podTemplate.ServiceAccountName != "" {
+		podSpec.ServiceAccountName = podTemplate.ServiceAccountName
+	}
+	if podTemplate.PriorityClassName != "" {
+		podSpec.PriorityClassName = podTemplate.PriorityClassName
+	}
+
+	if podTemplate.ContainerSecurityContext != nil {
+		for i := range podSpec.Containers {
+			podSpec.Containers[i].SecurityContext = podTemplate.ContainerSecurityContext
+		}
+	}
+}
+
+// mergeStringMaps returns the entries of values overridden by the entries of overrides
+func mergeStringMaps(values map[string]string, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(values)+len(overrides))
+	for key, value := range values {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// mergeMissing adds the entries of overrides which are not set in values
+func mergeMissing(values map[string]string, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return values
+	}
+	if values == nil {
+		values = make(map[string]string, len(overrides))
+	}
+	for key, value := range overrides {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return values
+}
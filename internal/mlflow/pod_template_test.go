@@ -0,0 +1,53 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObjectManager_ApplyPodTemplate(t *testing.T) {
+	om := &ObjectManager{}
+	allowPrivilegeEscalation := false
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{appLabelKey: "mlflow"},
+			Annotations: map[string]string{ConfigHashAnnotationKey: "hash"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "mlflow"}},
+		},
+	}
+	podTemplate := &mlflowv1beta1.PodTemplateSpec{
+		Labels:                   map[string]string{appLabelKey: "other", "team": "ml"},
+		Annotations:              map[string]string{"prometheus.io/scrape": "true"},
+		NodeSelector:             map[string]string{"pool": "ml"},
+		Tolerations:              []corev1.Toleration{{Key: "dedicated", Value: "ml", Effect: corev1.TaintEffectNoSchedule}},
+		ContainerSecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: &allowPrivilegeEscalation},
+		ServiceAccountName:       "mlflow",
+	}
+
+	om.ApplyPodTemplate(template, podTemplate)
+
+	if want := map[string]string{appLabelKey: "mlflow", "team": "ml"}; !reflect.DeepEqual(template.Labels, want) {
+		t.Errorf("ApplyPodTemplate() labels = %v, want %v", template.Labels, want)
+	}
+	if want := map[string]string{ConfigHashAnnotationKey: "hash", "prometheus.io/scrape": "true"}; !reflect.DeepEqual(template.Annotations, want) {
+		t.Errorf("ApplyPodTemplate() annotations = %v, want %v", template.Annotations, want)
+	}
+	if !reflect.DeepEqual(template.Spec.NodeSelector, podTemplate.NodeSelector) {
+		t.Errorf("ApplyPodTemplate() nodeSelector = %v, want %v", template.Spec.NodeSelector, podTemplate.NodeSelector)
+	}
+	if !reflect.DeepEqual(template.Spec.Tolerations, podTemplate.Tolerations) {
+		t.Errorf("ApplyPodTemplate() tolerations = %v, want %v", template.Spec.Tolerations, podTemplate.Tolerations)
+	}
+	if template.Spec.ServiceAccountName != "mlflow" {
+		t.Errorf("ApplyPodTemplate() serviceAccountName = %s, want mlflow", template.Spec.ServiceAccountName)
+	}
+	if template.Spec.Containers[0].SecurityContext != podTemplate.ContainerSecurityContext {
+		t.Errorf("ApplyPodTemplate() container securityContext = %v, want %v", template.Spec.Containers[0].SecurityContext, podTemplate.ContainerSecurityContext)
+	}
+}
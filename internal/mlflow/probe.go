@@ -0,0 +1,91 @@
+package mlflow
+
+import (
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	serverHealthPath    = "/health"
+	modelHealthPath     = "/ping"
+	mlserverReadyPath   = "/v2/health/ready"
+	mlserverLivePath    = "/v2/health/live"
+	serverStartupPeriod = 5
+	// model pods may create their conda or virtualenv environment on startup, which can take many minutes
+	modelStartupPeriod            = 10
+	modelStartupFailureThreshold  = 90
+	serverStartupFailureThreshold = 60
+)
+
+// setServerProbes sets the probes of the MLFlow server container, checking its health endpoint
+func setServerProbes(container *corev1.Container, config *mlflowv1beta1.MLFlow) {
+	port := intstr.FromInt32(defaultServerPort)
+	container.ReadinessProbe = newHTTPProbe(serverHealthPath, port, 10, 5, 3)
+	container.LivenessProbe = newHTTPProbe(serverHealthPath, port, 20, 5, 3)
+	container.StartupProbe = newHTTPProbe(serverHealthPath, port, serverStartupPeriod, 5, serverStartupFailureThreshold)
+
+	if config.Spec.Server != nil {
+		applyProbes(container, config.Spec.Server.Probes)
+	}
+}
+
+// setModelProbes sets the probes of the model container against the health endpoints of the serving runtime,
+// applying the probe timings of the model defaults and then the ones of the model
+func setModelProbes(container *corev1.Container, config ModelDeploymentObjectConfig, runtime mlflowv1beta1.ServingRuntime) {
+	readyPath, livePath := modelHealthPath, modelHealthPath
+	if runtime == mlflowv1beta1.ServingRuntimeMLServer {
+		readyPath, livePath = mlserverReadyPath, mlserverLivePath
+	}
+
+	port := intstr.FromString(modelPortName)
+	container.ReadinessProbe = newHTTPProbe(readyPath, port, 10, 5, 3)
+	container.LivenessProbe = newHTTPProbe(livePath, port, 20, 10, 3)
+	container.StartupProbe = newHTTPProbe(livePath, port, modelStartupPeriod, 5, modelStartupFailureThreshold)
+
+	if modelDefaults := config.MlFlowServerConfig.Spec.ModelDefaults; modelDefaults != nil {
+		applyProbes(container, modelDefaults.Probes)
+	}
+	applyProbes(container, config.Probes)
+}
+
+func newHTTPProbe(path string, port intstr.IntOrString, periodSeconds int32, timeoutSeconds int32, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: path,
+				Port: port,
+			},
+		},
+		PeriodSeconds:    periodSeconds,
+		TimeoutSeconds:   timeoutSeconds,
+		FailureThreshold: failureThreshold,
+	}
+}
+
+func applyProbes(container *corev1.Container, probes *mlflowv1beta1.ProbesSpec) {
+	if probes == nil {
+		return
+	}
+	applyProbeTimings(container.ReadinessProbe, probes.Readiness)
+	applyProbeTimings(container.LivenessProbe, probes.Liveness)
+	applyProbeTimings(container.StartupProbe, probes.Startup)
+}
+
+func applyProbeTimings(probe *corev1.Probe, timings *mlflowv1beta1.ProbeTimings) {
+	if timings == nil {
+		return
+	}
+	if timings.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *timings.InitialDelaySeconds
+	}
+	if timings.PeriodSeconds != nil {
+		probe.PeriodSeconds = *timings.PeriodSeconds
+	}
+	if timings.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *timings.TimeoutSeconds
+	}
+	if timings.FailureThreshold != nil {
+		probe.FailureThreshold = *timings.FailureThreshold
+	}
+}
@@ -0,0 +1,48 @@
+package mlflow
+
+import (
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSetModelProbes(t *testing.T) {
+	defaultsPeriod, defaultsThreshold, tagThreshold := int32(30), int32(20), int32(120)
+	config := ModelDeploymentObjectConfig{
+		MlFlowServerConfig: &mlflowv1beta1.MLFlow{
+			Spec: mlflowv1beta1.MLFlowSpec{
+				ModelDefaults: &mlflowv1beta1.ModelDefaultsSpec{
+					Probes: &mlflowv1beta1.ProbesSpec{
+						Startup: &mlflowv1beta1.ProbeTimings{PeriodSeconds: &defaultsPeriod, FailureThreshold: &defaultsThreshold},
+					},
+				},
+			},
+		},
+		Probes: &mlflowv1beta1.ProbesSpec{
+			Startup: &mlflowv1beta1.ProbeTimings{FailureThreshold: &tagThreshold},
+		},
+	}
+
+	container := &corev1.Container{}
+	setModelProbes(container, config, mlflowv1beta1.ServingRuntimeMLflow)
+
+	if container.ReadinessProbe.HTTPGet.Path != modelHealthPath || container.LivenessProbe.HTTPGet.Path != modelHealthPath {
+		t.Errorf("setModelProbes() paths = %s, %s, want %s", container.ReadinessProbe.HTTPGet.Path, container.LivenessProbe.HTTPGet.Path, modelHealthPath)
+	}
+	if startup := container.StartupProbe; startup.PeriodSeconds != defaultsPeriod || startup.FailureThreshold != tagThreshold {
+		t.Errorf("setModelProbes() startup period = %d, failureThreshold = %d, want %d, %d",
+			startup.PeriodSeconds, startup.FailureThreshold, defaultsPeriod, tagThreshold)
+	}
+}
+
+func TestSetServerProbes(t *testing.T) {
+	container := &corev1.Container{}
+	setServerProbes(container, &mlflowv1beta1.MLFlow{})
+
+	for _, probe := range []*corev1.Probe{container.ReadinessProbe, container.LivenessProbe, container.StartupProbe} {
+		if probe.HTTPGet.Path != serverHealthPath || probe.HTTPGet.Port.IntVal != defaultServerPort {
+			t.Errorf("setServerProbes() probe = %v, want %s on port %d", probe.HTTPGet, serverHealthPath, defaultServerPort)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package mlflow
+
+import (
+	"fmt"
+	"sort"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResolveModelResources overrides the default model resources of the MLFlow instance with the given ones and clamps
+// them to the model limits, returning a message for every clamped value.
+// Requests exceeding their limits are rejected.
+func (om *ObjectManager) ResolveModelResources(
+	config *mlflowv1beta1.MLFlow,
+	overrides corev1.ResourceRequirements,
+) (corev1.ResourceRequirements, []string, error) {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	if modelDefaults := config.Spec.ModelDefaults; modelDefaults != nil && modelDefaults.Resources != nil {
+		resources.Claims = modelDefaults.Resources.Claims
+		mergeResourceList(resources.Requests, modelDefaults.Resources.Requests)
+		mergeResourceList(resources.Limits, modelDefaults.Resources.Limits)
+	}
+	mergeResourceList(resources.Requests, overrides.Requests)
+	mergeResourceList(resources.Limits, overrides.Limits)
+
+	clamped := clampResourceList(resources.Requests, config.Spec.ModelLimits, "request")
+	clamped = append(clamped, clampResourceList(resources.Limits, config.Spec.ModelLimits, "limit")...)
+
+	for _, name := range sortedResourceNames(resources.Requests) {
+		request := resources.Requests[name]
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return resources, clamped, fmt.Errorf("%s request %s exceeds its limit %s", name, request.String(), limit.String())
+		}
+	}
+
+	return resources, clamped, nil
+}
+
+func mergeResourceList(resourceList corev1.ResourceList, overrides corev1.ResourceList) {
+	for name, quantity := range overrides {
+		resourceList[name] = quantity.DeepCopy()
+	}
+}
+
+// clampResourceList clamps the resources to their ceilings, returning the messages ordered by resource name
+// so they are the same on every sync
+func clampResourceList(resourceList corev1.ResourceList, ceilings corev1.ResourceList, kind string) []string {
+	var clamped []string
+	for _, name := range sortedResourceNames(resourceList) {
+		quantity := resourceList[name]
+		ceiling, ok := ceilings[name]
+		if !ok || quantity.Cmp(ceiling) <= 0 {
+			continue
+		}
+		clamped = append(clamped, fmt.Sprintf("%s %s %s is clamped to %s", name, kind, quantity.String(), ceiling.String()))
+		resourceList[name] = ceiling.DeepCopy()
+	}
+	return clamped
+}
+
+func sortedResourceNames(resourceList corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(resourceList))
+	for name := range resourceList {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,83 @@
+package mlflow
+
+import (
+	"reflect"
+	"testing"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestObjectManager_ResolveModelResources(t *testing.T) {
+	om := &ObjectManager{}
+	config := &mlflowv1beta1.MLFlow{
+		Spec: mlflowv1beta1.MLFlowSpec{
+			ModelDefaults: &mlflowv1beta1.ModelDefaultsSpec{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			},
+			ModelLimits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		overrides       corev1.ResourceRequirements
+		expected        corev1.ResourceRequirements
+		expectedClamped []string
+		wantErr         bool
+	}{
+		{
+			name: "defaults",
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+			},
+		},
+		{
+			name: "clamped to model limits",
+			overrides: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("16Gi")},
+			},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+			},
+			expectedClamped: []string{
+				"cpu request 4 is clamped to 2",
+				"memory request 8Gi is clamped to 4Gi",
+				"cpu limit 8 is clamped to 2",
+				"memory limit 16Gi is clamped to 4Gi",
+			},
+		},
+		{
+			name: "request exceeding limit",
+			overrides: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clamped, err := om.ResolveModelResources(config, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveModelResources() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(clamped, tt.expectedClamped) {
+				t.Errorf("ResolveModelResources() clamped = %v, want %v", clamped, tt.expectedClamped)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ResolveModelResources() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
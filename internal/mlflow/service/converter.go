@@ -1,6 +1,18 @@
 package service
 
-import "k8s.io/apimachinery/pkg/api/resource"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
 
 const (
 	tagPrefix            = "mlflowOperator-"
@@ -8,42 +20,350 @@ const (
 	cpuLimitTagName      = tagPrefix + "cpuLimit"
 	memoryRequestTagName = tagPrefix + "memoryRequest"
 	memoryLimitTagName   = tagPrefix + "memoryLimit"
+	replicasTagName      = tagPrefix + "replicas"
+	envManagerTagName    = tagPrefix + "envManager"
+	runtimeTagName       = tagPrefix + "runtime"
+	workersTagName       = tagPrefix + "workers"
+	timeoutTagName       = tagPrefix + "timeout"
+	nodeSelectorTagName  = tagPrefix + "nodeSelector"
+	imageTagName         = tagPrefix + "image"
+	canaryWeightTagName  = tagPrefix + "canaryWeight"
+	envTagPrefix         = tagPrefix + "env-"
+	resourceTagPrefix    = tagPrefix + "resource-"
+	probeTagSuffix       = "Probe-"
+	endpointTagName      = tagPrefix + "endpoint"
+	statusTagName        = tagPrefix + "status"
+	deployedAtTagName    = tagPrefix + "deployedAt"
+	errorTagName         = tagPrefix + "error"
+
+	trackingURIEnvName = "MLFLOW_TRACKING_URI"
+
+	// ModelVersionStatusDeployed is the status tag value of a model version served by the operator
+	ModelVersionStatusDeployed = "deployed"
+	// ModelVersionStatusFailed is the status tag value of a model version the operator failed to serve
+	ModelVersionStatusFailed = "failed"
 )
 
+// OperatorTags is the serving configuration of a model version set through its operator tags.
+// Unset values are left to the defaults of the MLFlow instance.
 type OperatorTags struct {
+	// NodeSelector is set by the nodeSelector tag as comma separated key=value pairs
+	NodeSelector map[string]string
+	// Probes are set by the <readiness|liveness|startup>Probe-<timing> tags, e.g. startupProbe-failureThreshold
+	Probes *mlflowv1beta1.ProbesSpec
+	// ExtendedResources are set by the resource-<name> tags, both as request and limit
+	ExtendedResources corev1.ResourceList
+	Replicas          *int32
+	Workers           *int32
+	// CanaryWeight is the percentage of the traffic of the alias first sent to the version while it is promoted,
+	// the canary steps above it follow
+	CanaryWeight  *int32
 	CPURequest    resource.Quantity
 	CPULimit      resource.Quantity
 	MemoryRequest resource.Quantity
 	MemoryLimit   resource.Quantity
+	EnvManager    mlflowv1beta1.EnvManager
+	Runtime       mlflowv1beta1.ServingRuntime
+	// Image overrides the model image, it is only used if allowed by the MLFlow instance
+	Image string
+	// Env is set by the env-<NAME> tags
+	Env     []corev1.EnvVar
+	Timeout time.Duration
 }
 
-// TODO: validate tags to fit kubernetes standards if validation fails update status of ml flow model via ml flow api
-// TODO: set default cpu and mem resources if related tag not found
-
 func (t Tags) GetOperatorTags() (mlFlowOperatorTags OperatorTags) {
+	mlFlowOperatorTags, _ = t.ParseOperatorTags()
+	return
+}
+
+// ParseOperatorTags returns the operator tags of the model version with an error for every invalid tag,
+// which is left unset
+func (t Tags) ParseOperatorTags() (OperatorTags, []error) {
+	var mlFlowOperatorTags OperatorTags
+	var errs []error
+
 	for _, tag := range t {
-		switch tag.Key {
-		case cpuRequestTagName:
-			quantity, err := resource.ParseQuantity(tag.Value)
-			if err == nil {
-				mlFlowOperatorTags.CPURequest = quantity
-			}
-		case cpuLimitTagName:
-			quantity, err := resource.ParseQuantity(tag.Value)
-			if err == nil {
-				mlFlowOperatorTags.CPULimit = quantity
-			}
-		case memoryRequestTagName:
-			quantity, err := resource.ParseQuantity(tag.Value)
-			if err == nil {
-				mlFlowOperatorTags.MemoryRequest = quantity
-			}
-		case memoryLimitTagName:
-			quantity, err := resource.ParseQuantity(tag.Value)
-			if err == nil {
-				mlFlowOperatorTags.MemoryLimit = quantity
-			}
+		if err := mlFlowOperatorTags.parseTag(tag.Key, tag.Value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s tag %q: %w", tag.Key, tag.Value, err))
 		}
 	}
-	return
+
+	sort.Slice(mlFlowOperatorTags.Env, func(i, j int) bool {
+		return mlFlowOperatorTags.Env[i].Name < mlFlowOperatorTags.Env[j].Name
+	})
+
+	return mlFlowOperatorTags, errs
+}
+
+func (o *OperatorTags) parseTag(key string, value string) error {
+	switch key {
+	case cpuRequestTagName:
+		return parseQuantity(value, &o.CPURequest)
+	case cpuLimitTagName:
+		return parseQuantity(value, &o.CPULimit)
+	case memoryRequestTagName:
+		return parseQuantity(value, &o.MemoryRequest)
+	case memoryLimitTagName:
+		return parseQuantity(value, &o.MemoryLimit)
+	case replicasTagName:
+		replicas, err := parseInt32(value, 0)
+		if err != nil {
+			return err
+		}
+		o.Replicas = &replicas
+	case workersTagName:
+		workers, err := parseInt32(value, 1)
+		if err != nil {
+			return err
+		}
+		o.Workers = &workers
+	case canaryWeightTagName:
+		weight, err := parseInt32(value, 1)
+		if err != nil {
+			return err
+		}
+		if weight > 100 {
+			return fmt.Errorf("must be at most 100")
+		}
+		o.CanaryWeight = &weight
+	case timeoutTagName:
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			return err
+		}
+		o.Timeout = timeout
+	case envManagerTagName:
+		envManager := mlflowv1beta1.EnvManager(value)
+		switch envManager {
+		case mlflowv1beta1.EnvManagerLocal, mlflowv1beta1.EnvManagerVirtualenv, mlflowv1beta1.EnvManagerConda:
+			o.EnvManager = envManager
+		default:
+			return fmt.Errorf("must be one of %s, %s, %s",
+				mlflowv1beta1.EnvManagerLocal, mlflowv1beta1.EnvManagerVirtualenv, mlflowv1beta1.EnvManagerConda)
+		}
+	case runtimeTagName:
+		runtime := mlflowv1beta1.ServingRuntime(value)
+		switch runtime {
+		case mlflowv1beta1.ServingRuntimeMLflow, mlflowv1beta1.ServingRuntimeMLServer:
+			o.Runtime = runtime
+		default:
+			return fmt.Errorf("must be one of %s, %s", mlflowv1beta1.ServingRuntimeMLflow, mlflowv1beta1.ServingRuntimeMLServer)
+		}
+	case nodeSelectorTagName:
+		nodeSelector, err := labels.ConvertSelectorToLabelsMap(value)
+		if err != nil {
+			return err
+		}
+		o.NodeSelector = nodeSelector
+	case imageTagName:
+		if value == "" {
+			return fmt.Errorf("must not be empty")
+		}
+		o.Image = value
+	default:
+		switch {
+		case strings.HasPrefix(key, envTagPrefix):
+			return o.parseEnv(strings.TrimPrefix(key, envTagPrefix), value)
+		case strings.HasPrefix(key, resourceTagPrefix):
+			return o.parseExtendedResource(corev1.ResourceName(strings.TrimPrefix(key, resourceTagPrefix)), value)
+		case strings.HasPrefix(key, tagPrefix) && strings.Contains(key, probeTagSuffix):
+			probe, timing, _ := strings.Cut(strings.TrimPrefix(key, tagPrefix), probeTagSuffix)
+			return o.parseProbeTiming(probe, timing, value)
+		}
+	}
+	return nil
+}
+
+func (o *OperatorTags) parseEnv(name string, value string) error {
+	if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+		return fmt.Errorf("invalid environment variable name: %s", strings.Join(errs, ", "))
+	}
+	if name == trackingURIEnvName {
+		return fmt.Errorf("%s is set by the operator", trackingURIEnvName)
+	}
+	o.Env = append(o.Env, corev1.EnvVar{Name: name, Value: value})
+	return nil
+}
+
+func (o *OperatorTags) parseExtendedResource(name corev1.ResourceName, value string) error {
+	if name == corev1.ResourceCPU || name == corev1.ResourceMemory {
+		return fmt.Errorf("%s is set by its request and limit tags", name)
+	}
+	if errs := validation.IsQualifiedName(string(name)); len(errs) > 0 {
+		return fmt.Errorf("invalid resource name: %s", strings.Join(errs, ", "))
+	}
+	var quantity resource.Quantity
+	if err := parseQuantity(value, &quantity); err != nil {
+		return err
+	}
+	if o.ExtendedResources == nil {
+		o.ExtendedResources = corev1.ResourceList{}
+	}
+	o.ExtendedResources[name] = quantity
+	return nil
+}
+
+func (o *OperatorTags) parseProbeTiming(probe string, timing string, value string) error {
+	var minimum int32 = 1
+	switch timing {
+	case "initialDelaySeconds":
+		minimum = 0
+	case "periodSeconds", "timeoutSeconds", "failureThreshold":
+	default:
+		return fmt.Errorf("unknown probe timing %s, must be one of initialDelaySeconds, periodSeconds, timeoutSeconds, failureThreshold", timing)
+	}
+	if probe != "readiness" && probe != "liveness" && probe != "startup" {
+		return fmt.Errorf("unknown probe %s, must be one of readiness, liveness, startup", probe)
+	}
+
+	number, err := parseInt32(value, minimum)
+	if err != nil {
+		return err
+	}
+
+	if o.Probes == nil {
+		o.Probes = &mlflowv1beta1.ProbesSpec{}
+	}
+	timings := map[string]**mlflowv1beta1.ProbeTimings{
+		"readiness": &o.Probes.Readiness,
+		"liveness":  &o.Probes.Liveness,
+		"startup":   &o.Probes.Startup,
+	}[probe]
+	if *timings == nil {
+		*timings = &mlflowv1beta1.ProbeTimings{}
+	}
+
+	switch timing {
+	case "initialDelaySeconds":
+		(*timings).InitialDelaySeconds = &number
+	case "periodSeconds":
+		(*timings).PeriodSeconds = &number
+	case "timeoutSeconds":
+		(*timings).TimeoutSeconds = &number
+	case "failureThreshold":
+		(*timings).FailureThreshold = &number
+	}
+	return nil
+}
+
+// ResourceRequirements returns the resources set by the operator tags
+func (o OperatorTags) ResourceRequirements() corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	setQuantity(resources.Requests, corev1.ResourceCPU, o.CPURequest)
+	setQuantity(resources.Limits, corev1.ResourceCPU, o.CPULimit)
+	setQuantity(resources.Requests, corev1.ResourceMemory, o.MemoryRequest)
+	setQuantity(resources.Limits, corev1.ResourceMemory, o.MemoryLimit)
+	for name, quantity := range o.ExtendedResources {
+		setQuantity(resources.Requests, name, quantity)
+		setQuantity(resources.Limits, name, quantity)
+	}
+	return resources
+}
+
+func setQuantity(resourceList corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	if !quantity.IsZero() {
+		resourceList[name] = quantity
+	}
+}
+
+func parseQuantity(value string, target *resource.Quantity) error {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return err
+	}
+	if quantity.Sign() < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	*target = quantity
+	return nil
+}
+
+func parseInt32(value string, minimum int32) (int32, error) {
+	number, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if int32(number) < minimum {
+		return 0, fmt.Errorf("must be at least %d", minimum)
+	}
+	return int32(number), nil
+}
+
+// parseTimeout accepts a duration such as 90s or a number of seconds
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, atoiErr := strconv.Atoi(value)
+		if atoiErr != nil {
+			return 0, err
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if timeout < time.Second {
+		return 0, fmt.Errorf("must be at least 1s")
+	}
+	return timeout, nil
+}
+
+// EndpointTagName returns the tag holding the in-cluster URL of the model version, or of its alias when the alias is set
+func EndpointTagName(alias string) string {
+	return aliasTagName(endpointTagName, alias)
+}
+
+// StatusTagName returns the tag holding the serving status of the model version, or of its alias when the alias is set
+func StatusTagName(alias string) string {
+	return aliasTagName(statusTagName, alias)
+}
+
+// DeployedAtTagName returns the tag holding the time the model version, or its alias when the alias is set, was deployed
+func DeployedAtTagName(alias string) string {
+	return aliasTagName(deployedAtTagName, alias)
+}
+
+// ErrorTagName returns the tag holding the reasons the operator rejected the configuration of the model version
+// or failed to deploy it, or its alias when the alias is set
+func ErrorTagName(alias string) string {
+	return aliasTagName(errorTagName, alias)
+}
+
+// isServingStateTag reports whether the tag is written by the operator to report the serving state of the model version
+// or of one of its aliases
+func isServingStateTag(key string) bool {
+	for _, tagName := range []string{endpointTagName, statusTagName, deployedAtTagName, errorTagName} {
+		if key == tagName || strings.HasPrefix(key, tagName+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+func aliasTagName(tagName string, alias string) string {
+	if alias != "" {
+		return tagName + "-" + alias
+	}
+	return tagName
+}
+
+// GetOperatorTagValues returns the raw values of the operator tags configuring the model deployment keyed by tag name,
+// leaving out the tags the operator writes the serving state to
+func (t Tags) GetOperatorTagValues() map[string]string {
+	values := make(map[string]string)
+	for _, tag := range t {
+		if strings.HasPrefix(tag.Key, tagPrefix) && !isServingStateTag(tag.Key) {
+			values[tag.Key] = tag.Value
+		}
+	}
+	return values
+}
+
+// ToMap returns the values of all tags keyed by tag name
+func (t Tags) ToMap() map[string]string {
+	values := make(map[string]string, len(t))
+	for _, tag := range t {
+		values[tag.Key] = tag.Value
+	}
+	return values
 }
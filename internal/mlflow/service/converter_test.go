@@ -3,15 +3,18 @@ package service
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestTags_GetOperatorTags(t *testing.T) {
 	tests := []struct {
-		wantMlFlowOperatorTags OperatorTags
 		name                   string
 		t                      Tags
+		wantMlFlowOperatorTags OperatorTags
 	}{
 		{
 			name: "should return all resource tags",
@@ -93,3 +96,127 @@ func TestTags_GetOperatorTags(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_GetOperatorTagValues(t *testing.T) {
+	tags := Tags{
+		{Key: "mlflowOperator-cpuRequest", Value: "100m"},
+		{Key: "mlflowOperator-memoryLimit", Value: "1G"},
+		{Key: "mlflowOperator-status-champion", Value: "deployed"},
+		{Key: "mlflowOperator-endpoint", Value: "http://mlflow-fraud-1.ml.svc.cluster.local:5000"},
+		{Key: "mlflowOperator-deployedAt", Value: "2024-01-01T00:00:00Z"},
+		{Key: "mlflowOperator-error-champion", Value: "invalid image"},
+		{Key: "owner", Value: "data-science"},
+	}
+
+	want := map[string]string{
+		"mlflowOperator-cpuRequest":  "100m",
+		"mlflowOperator-memoryLimit": "1G",
+	}
+	if got := tags.GetOperatorTagValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOperatorTagValues() = %v, want %v", got, want)
+	}
+}
+
+func TestTags_ParseOperatorTags(t *testing.T) {
+	tags := Tags{
+		{Key: "mlflowOperator-cpuRequest", Value: "hundred"},
+		{Key: "mlflowOperator-memoryLimit", Value: "1G"},
+	}
+
+	operatorTags, errs := tags.ParseOperatorTags()
+	if len(errs) != 1 {
+		t.Fatalf("ParseOperatorTags() errors = %v, want 1 error", errs)
+	}
+	want := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1G")},
+	}
+	if got := operatorTags.ResourceRequirements(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ResourceRequirements() = %v, want %v", got, want)
+	}
+}
+
+func TestTags_ParseOperatorTagsServingConfiguration(t *testing.T) {
+	tags := Tags{
+		{Key: "mlflowOperator-replicas", Value: "3"},
+		{Key: "mlflowOperator-workers", Value: "4"},
+		{Key: "mlflowOperator-timeout", Value: "90"},
+		{Key: "mlflowOperator-envManager", Value: "local"},
+		{Key: "mlflowOperator-nodeSelector", Value: "pool=gpu"},
+		{Key: "mlflowOperator-image", Value: "mlflow-gpu:2.12.1"},
+		{Key: "mlflowOperator-env-LOG_LEVEL", Value: "debug"},
+		{Key: "mlflowOperator-env-BATCH_SIZE", Value: "32"},
+		{Key: "mlflowOperator-resource-nvidia.com/gpu", Value: "1"},
+		{Key: "mlflowOperator-env-MLFLOW_TRACKING_URI", Value: "http://other"},
+		{Key: "mlflowOperator-workers", Value: "0"},
+		{Key: "mlflowOperator-envManager", Value: "docker"},
+		{Key: "mlflowOperator-canaryWeight", Value: "10"},
+		{Key: "mlflowOperator-canaryWeight", Value: "150"},
+	}
+
+	operatorTags, errs := tags.ParseOperatorTags()
+	if len(errs) != 4 {
+		t.Errorf("ParseOperatorTags() errors = %v, want 4 errors", errs)
+	}
+
+	replicas, workers, canaryWeight := int32(3), int32(4), int32(10)
+	want := OperatorTags{
+		NodeSelector:      map[string]string{"pool": "gpu"},
+		ExtendedResources: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+		Replicas:          &replicas,
+		Workers:           &workers,
+		CanaryWeight:      &canaryWeight,
+		Env:               []corev1.EnvVar{{Name: "BATCH_SIZE", Value: "32"}, {Name: "LOG_LEVEL", Value: "debug"}},
+		EnvManager:        "local",
+		Image:             "mlflow-gpu:2.12.1",
+		Timeout:           90 * time.Second,
+	}
+	if !reflect.DeepEqual(operatorTags, want) {
+		t.Errorf("ParseOperatorTags() = %v, want %v", operatorTags, want)
+	}
+}
+
+func TestTags_ParseOperatorTagsProbes(t *testing.T) {
+	tags := Tags{
+		{Key: "mlflowOperator-startupProbe-failureThreshold", Value: "180"},
+		{Key: "mlflowOperator-readinessProbe-initialDelaySeconds", Value: "0"},
+		{Key: "mlflowOperator-livenessProbe-periodSeconds", Value: "0"},
+		{Key: "mlflowOperator-warmupProbe-periodSeconds", Value: "10"},
+	}
+
+	operatorTags, errs := tags.ParseOperatorTags()
+	if len(errs) != 2 {
+		t.Errorf("ParseOperatorTags() errors = %v, want 2 errors", errs)
+	}
+
+	failureThreshold, initialDelaySeconds := int32(180), int32(0)
+	want := &mlflowv1beta1.ProbesSpec{
+		Readiness: &mlflowv1beta1.ProbeTimings{InitialDelaySeconds: &initialDelaySeconds},
+		Startup:   &mlflowv1beta1.ProbeTimings{FailureThreshold: &failureThreshold},
+	}
+	if !reflect.DeepEqual(operatorTags.Probes, want) {
+		t.Errorf("ParseOperatorTags() probes = %v, want %v", operatorTags.Probes, want)
+	}
+}
+
+func TestServingStateTagNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{name: "status", got: StatusTagName(""), expected: "mlflowOperator-status"},
+		{name: "alias status", got: StatusTagName("champion"), expected: "mlflowOperator-status-champion"},
+		{name: "deployedAt", got: DeployedAtTagName(""), expected: "mlflowOperator-deployedAt"},
+		{name: "alias endpoint", got: EndpointTagName("champion"), expected: "mlflowOperator-endpoint-champion"},
+		{name: "error", got: ErrorTagName(""), expected: "mlflowOperator-error"},
+		{name: "alias error", got: ErrorTagName("champion"), expected: "mlflowOperator-error-champion"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("tag name = %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
@@ -6,8 +6,14 @@ type RegisteredModelsResponse struct {
 }
 
 type RegisteredModel struct {
-	Name           string          `json:"name"`
-	LatestVersions []LatestVersion `json:"latest_versions"`
+	Name           string                 `json:"name"`
+	LatestVersions []LatestVersion        `json:"latest_versions"`
+	Aliases        []RegisteredModelAlias `json:"aliases"`
+}
+
+type RegisteredModelAlias struct {
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
 }
 
 type LatestVersion struct {
@@ -23,7 +29,11 @@ type ModelVersionsResponse struct {
 }
 
 type ModelVersion struct {
-	Version string `json:"version"`
+	Version              string   `json:"version"`
+	CurrentStage         string   `json:"current_stage"`
+	Tags                 Tags     `json:"tags"`
+	Aliases              []string `json:"aliases"`
+	LastUpdatedTimestamp int64    `json:"last_updated_timestamp"`
 }
 
 type ModelVersionDetailResponse struct {
@@ -31,17 +41,36 @@ type ModelVersionDetailResponse struct {
 }
 
 type ModelVersionDetail struct {
-	Name                 string `json:"name"`
-	Version              string `json:"version"`
-	CurrentStage         string `json:"current_stage"`
-	Description          string `json:"description"`
-	Source               string `json:"source"`
-	RunID                string `json:"run_id"`
-	Status               string `json:"status"`
-	RunLink              string `json:"run_link"`
-	Tags                 Tags   `json:"tags"`
-	CreationTimestamp    int64  `json:"creation_timestamp"`
-	LastUpdatedTimestamp int64  `json:"last_updated_timestamp"`
+	Name                 string   `json:"name"`
+	Version              string   `json:"version"`
+	CurrentStage         string   `json:"current_stage"`
+	Description          string   `json:"description"`
+	Source               string   `json:"source"`
+	RunID                string   `json:"run_id"`
+	Status               string   `json:"status"`
+	StatusMessage        string   `json:"status_message"`
+	RunLink              string   `json:"run_link"`
+	Tags                 Tags     `json:"tags"`
+	Aliases              []string `json:"aliases"`
+	CreationTimestamp    int64    `json:"creation_timestamp"`
+	LastUpdatedTimestamp int64    `json:"last_updated_timestamp"`
+}
+
+// Registration statuses of a model version
+const (
+	ModelVersionPendingRegistration = "PENDING_REGISTRATION"
+	ModelVersionFailedRegistration  = "FAILED_REGISTRATION"
+	ModelVersionReady               = "READY"
+)
+
+// IsPendingRegistration reports whether the artifacts of the model version are still being registered
+func (d ModelVersionDetail) IsPendingRegistration() bool {
+	return d.Status == ModelVersionPendingRegistration
+}
+
+// IsFailedRegistration reports whether the registration of the model version failed
+func (d ModelVersionDetail) IsFailedRegistration() bool {
+	return d.Status == ModelVersionFailedRegistration
 }
 
 type Tags []ModelVersionTag
@@ -51,6 +80,8 @@ type ModelVersionTag struct {
 	Value string `json:"value"`
 }
 
+type SetModelVersionTagResponse struct{}
+
 type UpdateDescriptionResponse struct {
 	RegisteredModel RegisteredModel `json:"registered_model"`
 }
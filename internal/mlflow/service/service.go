@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
 	"github.com/Trendyol/mlflow-operator/internal/mlflow"
 	"github.com/Trendyol/mlflow-operator/internal/util"
+	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	defaultNamespace = "default"
+
+	registeredModelsPageSize = 1000
+	modelVersionsPageSize    = 10000
 )
 
 type Client struct {
@@ -26,49 +33,128 @@ func NewClient(mlflowServerCfg *mlflowv1beta1.MLFlow, httpClient util.HTTPClient
 		httpClient: httpClient,
 	}
 
+	port := mlflow.ServerServicePort(mlflowServerCfg)
 	if mlflowServerCfg.Namespace == defaultNamespace {
-		client.BaseURL = fmt.Sprintf("http://%s:5000/api/2.0/mlflow", mlflowServerCfg.Name)
+		client.BaseURL = fmt.Sprintf("http://%s:%d/api/2.0/mlflow", mlflowServerCfg.Name, port)
 	} else {
-		client.BaseURL = fmt.Sprintf("http://%s.%s:5000/api/2.0/mlflow", mlflowServerCfg.Name, mlflowServerCfg.Namespace)
+		client.BaseURL = fmt.Sprintf("http://%s.%s:%d/api/2.0/mlflow", mlflowServerCfg.Name, mlflowServerCfg.Namespace, port)
 	}
 
 	if debug {
-		client.BaseURL = "http://localhost:30099/api/2.0/mlflow"
+		client.BaseURL = fmt.Sprintf("http://localhost:%d/api/2.0/mlflow", debugNodePort(mlflowServerCfg))
+	}
+
+	if clientSpec := mlflowServerCfg.Spec.Client; clientSpec != nil && clientSpec.URL != "" {
+		client.BaseURL = strings.TrimSuffix(clientSpec.URL, "/") + "/api/2.0/mlflow"
 	}
 
 	return client
 }
 
-func (m *Client) GetLatestModels() (mlflow.Models, error) {
+// debugNodePort returns the node port the operator reaches the MLFlow server through when running outside the cluster
+func debugNodePort(mlflowServerCfg *mlflowv1beta1.MLFlow) int32 {
+	if mlflowServerCfg.Spec.Service == nil || mlflowServerCfg.Spec.Service.Type == corev1.ServiceTypeClusterIP {
+		return 0
+	}
+	return mlflowServerCfg.Spec.Service.NodePort
+}
+
+// ValidateDebugAccess returns an error when the operator running outside the cluster cannot reach the MLFlow server,
+// which needs the client URL or a fixed node port on the server Service
+func ValidateDebugAccess(mlflowServerCfg *mlflowv1beta1.MLFlow) error {
+	if clientSpec := mlflowServerCfg.Spec.Client; clientSpec != nil && clientSpec.URL != "" {
+		return nil
+	}
+	if debugNodePort(mlflowServerCfg) == 0 {
+		return fmt.Errorf("debug mode reaches the MLflow server %q on localhost through a node port, "+
+			"set spec.service.type to NodePort with spec.service.nodePort, or set spec.client.url", mlflowServerCfg.Name)
+	}
+	return nil
+}
+
+// ScanOptions configure how the model registry is scanned
+type ScanOptions struct {
+	// Tags filter the model versions on the server by their tag values
+	Tags map[string]string
+	// Concurrency is the number of registered models whose versions are searched at once
+	Concurrency int
+}
+
+// GetLatestModels returns the versions of every registered model matching the options,
+// searching the versions of the registered models concurrently
+func (m *Client) GetLatestModels(ctx context.Context, options ScanOptions) (mlflow.Models, error) {
+	registeredModels, err := m.searchRegisteredModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([][]ModelVersion, len(registeredModels))
+	errs := make([]error, len(registeredModels))
+	util.ForEachConcurrently(len(registeredModels), options.Concurrency, func(i int) {
+		versions[i], errs[i] = m.getModelVersions(ctx, registeredModels[i].Name, options.Tags)
+	})
+
 	var models mlflow.Models
+	for i, model := range registeredModels {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		latestInStage := make(map[string]string, len(model.LatestVersions))
+		for _, latestVersion := range model.LatestVersions {
+			latestInStage[latestVersion.Version] = latestVersion.CurrentStage
+		}
+
+		aliases := make(map[string][]string, len(model.Aliases))
+		for _, alias := range model.Aliases {
+			aliases[alias.Version] = append(aliases[alias.Version], alias.Alias)
+		}
+
+		for _, version := range versions[i] {
+			versionAliases := version.Aliases
+			if len(versionAliases) == 0 {
+				versionAliases = aliases[version.Version]
+			}
+
+			stage, isLatestInStage := latestInStage[version.Version]
+			if version.CurrentStage != "" {
+				stage = version.CurrentStage
+			}
+
+			models = append(models, mlflow.Model{
+				Name:                 model.Name,
+				Version:              version.Version,
+				Stage:                stage,
+				IsLatestInStage:      isLatestInStage,
+				Tags:                 version.Tags.ToMap(),
+				Aliases:              versionAliases,
+				LastUpdatedTimestamp: version.LastUpdatedTimestamp,
+			})
+		}
+	}
+
+	return models, nil
+}
+
+func (m *Client) searchRegisteredModels(ctx context.Context) ([]RegisteredModel, error) {
+	var registeredModels []RegisteredModel
 	var nextPageToken *string
 
 	for {
 		var response RegisteredModelsResponse
-		var err error
+
+		queryParams := url.Values{}
+		queryParams.Add("max_results", strconv.Itoa(registeredModelsPageSize))
 		if nextPageToken != nil {
-			err = m.httpClient.SendGetRequest(fmt.Sprintf("%s/registered-models/search?page_token=%s", m.BaseURL, *nextPageToken), &response)
-		} else {
-			err = m.httpClient.SendGetRequest(fmt.Sprintf("%s/registered-models/search", m.BaseURL), &response)
+			queryParams.Add("page_token", *nextPageToken)
 		}
 
+		err := m.httpClient.SendGetRequest(ctx, fmt.Sprintf("%s/registered-models/search?%s", m.BaseURL, queryParams.Encode()), &response)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, model := range response.RegisteredModels {
-			versions, err := m.getModelVersions(model.Name)
-			if err != nil {
-				return nil, err
-			}
-
-			for _, version := range versions {
-				models = append(models, mlflow.Model{
-					Name:    model.Name,
-					Version: version.Version,
-				})
-			}
-		}
+		registeredModels = append(registeredModels, response.RegisteredModels...)
 
 		if response.NextPageToken == nil {
 			break
@@ -77,11 +163,10 @@ func (m *Client) GetLatestModels() (mlflow.Models, error) {
 		}
 	}
 
-	return models, nil
+	return registeredModels, nil
 }
 
-func (m *Client) UpdateDescription(name string, message string) error {
-	ctx := context.Background()
+func (m *Client) UpdateDescription(ctx context.Context, name string, message string) error {
 	logger := log.FromContext(ctx)
 
 	req := map[string]interface{}{
@@ -90,7 +175,7 @@ func (m *Client) UpdateDescription(name string, message string) error {
 	}
 
 	var r UpdateDescriptionResponse
-	err := m.httpClient.SendPatchRequest(fmt.Sprintf("%s/registered-models/update", m.BaseURL), req, &r)
+	err := m.httpClient.SendPatchRequest(ctx, fmt.Sprintf("%s/registered-models/update", m.BaseURL), req, &r)
 	if err != nil {
 		logger.V(1).Error(err, "unable to update description")
 		return err
@@ -101,21 +186,26 @@ func (m *Client) UpdateDescription(name string, message string) error {
 	return nil
 }
 
-func (m *Client) getModelVersions(name string) ([]ModelVersion, error) {
+func (m *Client) getModelVersions(ctx context.Context, name string, tags map[string]string) ([]ModelVersion, error) {
 	var versions []ModelVersion
 	var nextPageToken *string
 
+	filter, err := modelVersionsFilter(name, tags)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		var response ModelVersionsResponse
 
 		queryParams := url.Values{}
-		queryParams.Add("filter", fmt.Sprintf("name='%s'", name))
+		queryParams.Add("filter", filter)
+		queryParams.Add("max_results", strconv.Itoa(modelVersionsPageSize))
 
 		if nextPageToken != nil {
 			queryParams.Add("page_token", *nextPageToken)
 		}
 
-		err := m.httpClient.SendGetRequest(fmt.Sprintf("%s/model-versions/search?%s", m.BaseURL, queryParams.Encode()), &response)
+		err := m.httpClient.SendGetRequest(ctx, fmt.Sprintf("%s/model-versions/search?%s", m.BaseURL, queryParams.Encode()), &response)
 		if err != nil {
 			return nil, err
 		}
@@ -132,15 +222,76 @@ func (m *Client) getModelVersions(name string) ([]ModelVersion, error) {
 	return versions, nil
 }
 
-func (m *Client) GetModelVersionDetail(name, version string) (*ModelVersionDetailResponse, error) {
+// modelVersionsFilter returns the search filter of the versions of the registered model having the given tag values
+func modelVersionsFilter(name string, tags map[string]string) (string, error) {
+	quotedName, err := quoteFilterValue(name)
+	if err != nil {
+		return "", err
+	}
+	conditions := []string{"name=" + quotedName}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		quotedValue, err := quoteFilterValue(tags[key])
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("tags.`%s`=%s", key, quotedValue))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// quoteFilterValue quotes a string value of the search filter. The server strips the quotes without unescaping
+// the value, so a value with a single quote is double quoted and a value with both quotes cannot be searched.
+func quoteFilterValue(value string) (string, error) {
+	if !strings.Contains(value, "'") {
+		return "'" + value + "'", nil
+	}
+	if !strings.Contains(value, `"`) {
+		return `"` + value + `"`, nil
+	}
+	return "", fmt.Errorf("%q contains both single and double quotes and cannot be used in a search filter", value)
+}
+
+func (m *Client) GetModelVersionDetail(ctx context.Context, name, version string) (*ModelVersionDetailResponse, error) {
 	var response ModelVersionDetailResponse
 	queryParams := url.Values{}
 	queryParams.Add("name", name)
 	queryParams.Add("version", version)
-	err := m.httpClient.SendGetRequest(fmt.Sprintf("%s/model-versions/get?%s", m.BaseURL, queryParams.Encode()), &response)
+	err := m.httpClient.SendGetRequest(ctx, fmt.Sprintf("%s/model-versions/get?%s", m.BaseURL, queryParams.Encode()), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetModelVersionByAlias resolves the model version the alias of the registered model points to
+func (m *Client) GetModelVersionByAlias(ctx context.Context, name, alias string) (*ModelVersionDetailResponse, error) {
+	var response ModelVersionDetailResponse
+	queryParams := url.Values{}
+	queryParams.Add("name", name)
+	queryParams.Add("alias", alias)
+	err := m.httpClient.SendGetRequest(ctx, fmt.Sprintf("%s/registered-models/alias?%s", m.BaseURL, queryParams.Encode()), &response)
 	if err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
+
+// SetModelVersionTag sets the tag on the model version
+func (m *Client) SetModelVersionTag(ctx context.Context, name, version, key, value string) error {
+	req := map[string]interface{}{
+		"name":    name,
+		"version": version,
+		"key":     key,
+		"value":   value,
+	}
+
+	var r SetModelVersionTagResponse
+	return m.httpClient.SendPostRequest(ctx, fmt.Sprintf("%s/model-versions/set-tag", m.BaseURL), req, &r)
+}
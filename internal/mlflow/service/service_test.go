@@ -1,22 +1,26 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
 
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
 	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	"github.com/Trendyol/mlflow-operator/internal/util"
 	"github.com/Trendyol/mlflow-operator/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // nolint:typecheck
 func TestGetLatestModels(t *testing.T) {
 	// given
 	responses := map[string]string{
-		"http://example.com/registered-models/search":                         generateRegisteredModelsResponse(),
-		"http://example.com/model-versions/search?filter=name%3D%27ModelA%27": generateModelVersionResponse(),
-		"http://example.com/model-versions/search?filter=name%3D%27ModelB%27": generateModelVersionResponse(),
-		"http://example.com/model-versions/search?filter=name%3D%27ModelC%27": generateModelVersionResponse(),
+		"http://example.com/registered-models/search?max_results=1000":                          generateRegisteredModelsResponse(),
+		"http://example.com/model-versions/search?filter=name%3D%27ModelA%27&max_results=10000": generateModelVersionResponse(),
+		"http://example.com/model-versions/search?filter=name%3D%27ModelB%27&max_results=10000": generateModelVersionResponse(),
+		"http://example.com/model-versions/search?filter=name%3D%27ModelC%27&max_results=10000": generateModelVersionResponse(),
 	}
 	mockClient := &mock.MockHTTPClient{
 		Responses: responses,
@@ -28,7 +32,7 @@ func TestGetLatestModels(t *testing.T) {
 	}
 
 	// when
-	models, err := client.GetLatestModels()
+	models, err := client.GetLatestModels(context.Background(), ScanOptions{Concurrency: 2})
 	// then
 	if err != nil {
 		t.Errorf("Expected no error, but got: %v", err)
@@ -90,13 +94,91 @@ func TestUpdateDescription(t *testing.T) {
 	}
 
 	// when
-	err := client.UpdateDescription("ModelA", "message")
+	err := client.UpdateDescription(context.Background(), "ModelA", "message")
 	// then
 	if err != nil {
 		t.Errorf("Expected no error, but got: %v", err)
 	}
 }
 
+func TestGetLatestModelsWithAliases(t *testing.T) {
+	// given
+	registeredModels, _ := json.Marshal(RegisteredModelsResponse{
+		RegisteredModels: []RegisteredModel{{
+			Name:    "ModelA",
+			Aliases: []RegisteredModelAlias{{Alias: "champion", Version: "2"}},
+		}},
+	})
+	responses := map[string]string{
+		"http://example.com/registered-models/search?max_results=1000":                          string(registeredModels),
+		"http://example.com/model-versions/search?filter=name%3D%27ModelA%27&max_results=10000": generateModelVersionResponse(),
+	}
+	client := &Client{
+		httpClient: &mock.MockHTTPClient{Responses: responses},
+		BaseURL:    "http://example.com",
+	}
+
+	// when
+	models, err := client.GetLatestModels(context.Background(), ScanOptions{Concurrency: 2})
+	// then
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	for _, model := range models {
+		hasAlias := len(model.Aliases) == 1 && model.Aliases[0] == "champion"
+		if hasAlias != (model.Version == "2") {
+			t.Errorf("Unexpected aliases %v for version %s", model.Aliases, model.Version)
+		}
+	}
+}
+
+func TestGetModelVersionByAlias(t *testing.T) {
+	// given
+	response, _ := json.Marshal(ModelVersionDetailResponse{ModelVersionDetail{
+		Name:    "ModelA",
+		Version: "2",
+		Aliases: []string{"champion"},
+	}})
+	responses := map[string]string{
+		"http://example.com/registered-models/alias?alias=champion&name=ModelA": string(response),
+	}
+	client := &Client{
+		httpClient: &mock.MockHTTPClient{Responses: responses},
+		BaseURL:    "http://example.com",
+	}
+
+	// when
+	modelVersion, err := client.GetModelVersionByAlias(context.Background(), "ModelA", "champion")
+	// then
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if modelVersion.Version != "2" {
+		t.Errorf("Expected version 2, but got %s", modelVersion.Version)
+	}
+}
+
+func TestGetModelVersionByAliasNotFound(t *testing.T) {
+	// given
+	errs := map[string]error{
+		"http://example.com/registered-models/alias?alias=champion&name=ModelA": &util.MLflowError{
+			ErrorCode:  util.ErrorCodeResourceDoesNotExist,
+			StatusCode: 404,
+		},
+	}
+	client := &Client{
+		httpClient: &mock.MockHTTPClient{Errors: errs},
+		BaseURL:    "http://example.com",
+	}
+
+	// when
+	_, err := client.GetModelVersionByAlias(context.Background(), "ModelA", "champion")
+	// then
+	if !util.IsResourceDoesNotExist(err) {
+		t.Errorf("Expected %s error, but got: %v", util.ErrorCodeResourceDoesNotExist, err)
+	}
+}
+
 func generateRegisteredModelsResponse() string {
 	latestVersion1 := LatestVersion{
 		Name:         "Model1",
@@ -174,3 +256,180 @@ func generateModelVersionResponse() string {
 
 	return string(jsonResponse)
 }
+
+func TestSetModelVersionTag(t *testing.T) {
+	// given
+	responses := map[string]string{
+		"http://example.com/model-versions/set-tag": "{}",
+	}
+	client := &Client{
+		httpClient: &mock.MockHTTPClient{Responses: responses},
+		BaseURL:    "http://example.com",
+	}
+
+	// when
+	err := client.SetModelVersionTag(context.Background(), "ModelA", "1", EndpointTagName(""), "http://mlflow-modela-1.default.svc.cluster.local:5000")
+	// then
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		mlflowServerCfg *mlflowv1beta1.MLFlow
+		name            string
+		expected        string
+		debug           bool
+	}{
+		{
+			name:            "default namespace and port",
+			mlflowServerCfg: &mlflowv1beta1.MLFlow{ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "default"}},
+			expected:        "http://mlflow:5000/api/2.0/mlflow",
+		},
+		{
+			name: "service port",
+			mlflowServerCfg: &mlflowv1beta1.MLFlow{
+				ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+				Spec:       mlflowv1beta1.MLFlowSpec{Service: &mlflowv1beta1.ServiceSpec{Port: 80}},
+			},
+			expected: "http://mlflow.ml:80/api/2.0/mlflow",
+		},
+		{
+			name: "debug node port",
+			mlflowServerCfg: &mlflowv1beta1.MLFlow{
+				ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+				Spec:       mlflowv1beta1.MLFlowSpec{Service: &mlflowv1beta1.ServiceSpec{Type: "NodePort", NodePort: 30100}},
+			},
+			debug:    true,
+			expected: "http://localhost:30100/api/2.0/mlflow",
+		},
+		{
+			name: "client url",
+			mlflowServerCfg: &mlflowv1beta1.MLFlow{
+				ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"},
+				Spec:       mlflowv1beta1.MLFlowSpec{Client: &mlflowv1beta1.ClientSpec{URL: "https://mlflow.example.com/"}},
+			},
+			expected: "https://mlflow.example.com/api/2.0/mlflow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if client := NewClient(tt.mlflowServerCfg, &mock.MockHTTPClient{}, tt.debug); client.BaseURL != tt.expected {
+				t.Errorf("NewClient().BaseURL = %s, want %s", client.BaseURL, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModelVersionsFilter(t *testing.T) {
+	tests := []struct {
+		tags      map[string]string
+		name      string
+		modelName string
+		expected  string
+		wantErr   bool
+	}{
+		{name: "name", modelName: "ModelA", expected: "name='ModelA'"},
+		{
+			name:      "tags",
+			modelName: "ModelA",
+			tags:      map[string]string{"team": "fraud", "serve": "true"},
+			expected:  "name='ModelA' AND tags.`serve`='true' AND tags.`team`='fraud'",
+		},
+		{
+			name:      "quoted name and tag value",
+			modelName: "Fraud's model",
+			tags:      map[string]string{"owner": "data science's"},
+			expected:  `name="Fraud's model" AND tags.` + "`owner`" + `="data science's"`,
+		},
+		{
+			name:      "single and double quoted name",
+			modelName: `Fraud's "best" model`,
+			wantErr:   true,
+		},
+		{
+			name:      "single and double quoted tag value",
+			modelName: "ModelA",
+			tags:      map[string]string{"owner": `data science's "core"`},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := modelVersionsFilter(tt.modelName, tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("modelVersionsFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("modelVersionsFilter() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModelVersionDetailRegistrationStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		wantPending bool
+		wantFailed  bool
+	}{
+		{name: "ready", status: ModelVersionReady},
+		{name: "pending", status: ModelVersionPendingRegistration, wantPending: true},
+		{name: "failed", status: ModelVersionFailedRegistration, wantFailed: true},
+		{name: "no status", status: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := ModelVersionDetail{Status: tt.status}
+			if got := detail.IsPendingRegistration(); got != tt.wantPending {
+				t.Errorf("IsPendingRegistration() = %v, want %v", got, tt.wantPending)
+			}
+			if got := detail.IsFailedRegistration(); got != tt.wantFailed {
+				t.Errorf("IsFailedRegistration() = %v, want %v", got, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestValidateDebugAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    mlflowv1beta1.MLFlowSpec
+		wantErr bool
+	}{
+		{
+			name:    "default service",
+			wantErr: true,
+		},
+		{
+			name:    "cluster ip service",
+			spec:    mlflowv1beta1.MLFlowSpec{Service: &mlflowv1beta1.ServiceSpec{Type: "ClusterIP", NodePort: 30100}},
+			wantErr: true,
+		},
+		{
+			name:    "node port allocated by the cluster",
+			spec:    mlflowv1beta1.MLFlowSpec{Service: &mlflowv1beta1.ServiceSpec{Type: "NodePort"}},
+			wantErr: true,
+		},
+		{
+			name: "node port",
+			spec: mlflowv1beta1.MLFlowSpec{Service: &mlflowv1beta1.ServiceSpec{Type: "NodePort", NodePort: 30100}},
+		},
+		{
+			name: "client url",
+			spec: mlflowv1beta1.MLFlowSpec{Client: &mlflowv1beta1.ClientSpec{URL: "http://localhost:5000"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mlflowServerCfg := &mlflowv1beta1.MLFlow{ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: "ml"}, Spec: tt.spec}
+			if err := ValidateDebugAccess(mlflowServerCfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDebugAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
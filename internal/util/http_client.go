@@ -2,75 +2,155 @@ package util
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
+const defaultRequestTimeout = 30 * time.Second
+
 type HTTPClient interface {
-	SendGetRequest(url string, target interface{}) error
-	SendPatchRequest(url string, data interface{}, target interface{}) error
+	SendGetRequest(ctx context.Context, url string, target interface{}) error
+	SendPostRequest(ctx context.Context, url string, data interface{}, target interface{}) error
+	SendPatchRequest(ctx context.Context, url string, data interface{}, target interface{}) error
+	SendDeleteRequest(ctx context.Context, url string, data interface{}, target interface{}) error
+}
+
+// HTTPClientOptions configures the authentication, TLS and timeout of the requests, basic auth is used if Username is set
+type HTTPClientOptions struct {
+	Username string
+	Password string
+	Token    string
+	// CABundle holds the PEM encoded certificates trusted in addition to the system ones
+	CABundle []byte
+	// Timeout of a request including its retries, defaults to 30 seconds
+	Timeout time.Duration
 }
 
 type httpClient struct {
-	client *retryablehttp.Client
+	client  *retryablehttp.Client
+	options HTTPClientOptions
 }
 
 func NewHTTPClient() HTTPClient {
-	retryableClient := retryablehttp.NewClient()
-	retryableClient.RetryMax = 5
-
 	return &httpClient{
-		client: retryableClient,
+		client: newRetryableClient(),
 	}
 }
 
-func (h *httpClient) SendGetRequest(url string, target interface{}) error {
-	resp, err := h.client.Get(url)
-	if err != nil {
-		return err
+// NewHTTPClientWithOptions returns an HTTPClient authenticating every request and trusting the given CA bundle
+func NewHTTPClientWithOptions(options HTTPClientOptions) (HTTPClient, error) {
+	retryableClient := newRetryableClient()
+
+	if len(options.CABundle) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(options.CABundle) {
+			return nil, errors.New("CA bundle has no valid PEM encoded certificate")
+		}
+
+		transport, ok := retryableClient.HTTPClient.Transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("unexpected transport %T", retryableClient.HTTPClient.Transport)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP request failed with status: %s", resp.Status)
-	}
+	return &httpClient{
+		client:  retryableClient,
+		options: options,
+	}, nil
+}
 
-	r := json.NewDecoder(resp.Body).Decode(target)
-	err = resp.Body.Close()
-	if err != nil {
-		return err
-	}
+func newRetryableClient() *retryablehttp.Client {
+	retryableClient := retryablehttp.NewClient()
+	retryableClient.RetryMax = 5
+	// return the last response once the retries are exhausted, so its MLflow error can be decoded
+	retryableClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	return retryableClient
+}
 
-	return r
+func (h *httpClient) SendGetRequest(ctx context.Context, url string, target interface{}) error {
+	return h.send(ctx, http.MethodGet, url, nil, target)
 }
 
-func (h *httpClient) SendPatchRequest(url string, data interface{}, target interface{}) error {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
+func (h *httpClient) SendPostRequest(ctx context.Context, url string, data interface{}, target interface{}) error {
+	return h.send(ctx, http.MethodPost, url, data, target)
+}
+
+func (h *httpClient) SendPatchRequest(ctx context.Context, url string, data interface{}, target interface{}) error {
+	return h.send(ctx, http.MethodPatch, url, data, target)
+}
+
+func (h *httpClient) SendDeleteRequest(ctx context.Context, url string, data interface{}, target interface{}) error {
+	return h.send(ctx, http.MethodDelete, url, data, target)
+}
+
+// send sends the request with data encoded as JSON and decodes the response into target,
+// returning an *MLflowError for unsuccessful responses
+func (h *httpClient) send(ctx context.Context, method string, url string, data interface{}, target interface{}) error {
+	timeout := h.options.Timeout
+	if timeout == 0 {
+		timeout = defaultRequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(jsonData)
 	}
 
-	req, err := retryablehttp.NewRequest("PATCH", url, bytes.NewBuffer(jsonData))
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return err
 	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	h.authorize(req)
 
 	resp, err := h.client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP request failed with status: %s", resp.Status)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newMLflowError(resp)
 	}
 
-	r := json.NewDecoder(resp.Body).Decode(target)
-	err = resp.Body.Close()
-	if err != nil {
-		return err
+	if target == nil {
+		return nil
 	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
 
-	return r
+// authorize sets the credentials of the client on the request
+func (h *httpClient) authorize(req *retryablehttp.Request) {
+	switch {
+	case h.options.Username != "":
+		req.SetBasicAuth(h.options.Username, h.options.Password)
+	case h.options.Token != "":
+		req.Header.Set("Authorization", "Bearer "+h.options.Token)
+	}
 }
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_SendGetRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":"RESOURCE_DOES_NOT_EXIST","message":"Registered Model with name=churn not found"}`))
+		case "/invalid":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error_code":"INVALID_PARAMETER_VALUE","message":"invalid filter"}`))
+		default:
+			_, _ = w.Write([]byte(`{"name":"churn"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithOptions(HTTPClientOptions{Token: "secret", Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithOptions() error = %v", err)
+	}
+
+	var response struct {
+		Name string `json:"name"`
+	}
+	if err := client.SendGetRequest(context.Background(), server.URL+"/ok", &response); err != nil || response.Name != "churn" {
+		t.Errorf("SendGetRequest() = %v, %v, want churn", response, err)
+	}
+
+	err = client.SendGetRequest(context.Background(), server.URL+"/missing", &response)
+	if !IsResourceDoesNotExist(err) || IsTransient(err) {
+		t.Errorf("SendGetRequest() error = %v, want %s", err, ErrorCodeResourceDoesNotExist)
+	}
+
+	err = client.SendGetRequest(context.Background(), server.URL+"/invalid", &response)
+	var mlflowErr *MLflowError
+	if !IsInvalidParameterValue(err) || !errors.As(err, &mlflowErr) || mlflowErr.Message != "invalid filter" {
+		t.Errorf("SendGetRequest() error = %v, want %s", err, ErrorCodeInvalidParameterValue)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		expected bool
+	}{
+		{name: "server error", err: &MLflowError{StatusCode: http.StatusServiceUnavailable}, expected: true},
+		{name: "too many requests", err: &MLflowError{StatusCode: http.StatusTooManyRequests}, expected: true},
+		{name: "deadline", err: context.DeadlineExceeded, expected: true},
+		{name: "not found", err: &MLflowError{StatusCode: http.StatusNotFound, ErrorCode: ErrorCodeResourceDoesNotExist}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.expected {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+const (
+	// ErrorCodeResourceDoesNotExist is returned by MLflow when the requested model or version does not exist
+	ErrorCodeResourceDoesNotExist = "RESOURCE_DOES_NOT_EXIST"
+	// ErrorCodeInvalidParameterValue is returned by MLflow when a request parameter is rejected
+	ErrorCodeInvalidParameterValue = "INVALID_PARAMETER_VALUE"
+
+	maxErrorBodySize = 64 * 1024
+)
+
+// MLflowError is an unsuccessful response of the MLflow API
+type MLflowError struct {
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"-"`
+}
+
+func (e *MLflowError) Error() string {
+	if e.ErrorCode == "" {
+		return fmt.Sprintf("HTTP request failed with status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("HTTP request failed with status: %d %s: %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+// newMLflowError decodes the MLflow error payload of the response, if any
+func newMLflowError(resp *http.Response) *MLflowError {
+	mlflowErr := &MLflowError{}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err == nil {
+		_ = json.Unmarshal(body, mlflowErr)
+	}
+	mlflowErr.StatusCode = resp.StatusCode
+	return mlflowErr
+}
+
+// IsResourceDoesNotExist reports whether the MLflow API rejected the request because the resource does not exist
+func IsResourceDoesNotExist(err error) bool {
+	var mlflowErr *MLflowError
+	return errors.As(err, &mlflowErr) &&
+		(mlflowErr.ErrorCode == ErrorCodeResourceDoesNotExist || (mlflowErr.ErrorCode == "" && mlflowErr.StatusCode == http.StatusNotFound))
+}
+
+// IsInvalidParameterValue reports whether the MLflow API rejected a parameter of the request
+func IsInvalidParameterValue(err error) bool {
+	var mlflowErr *MLflowError
+	return errors.As(err, &mlflowErr) && mlflowErr.ErrorCode == ErrorCodeInvalidParameterValue
+}
+
+// IsTransient reports whether the request failed because the MLflow server is unreachable or overloaded,
+// so that it may succeed later
+func IsTransient(err error) bool {
+	var mlflowErr *MLflowError
+	if errors.As(err, &mlflowErr) {
+		return mlflowErr.StatusCode >= http.StatusInternalServerError || mlflowErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
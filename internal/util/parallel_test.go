@@ -0,0 +1,30 @@
+package util
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	var running, maxRunning, calls int32
+	ForEachConcurrently(20, 3, func(int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&calls, 1)
+	})
+
+	if calls != 20 {
+		t.Errorf("ForEachConcurrently() calls = %d, want 20", calls)
+	}
+	if maxRunning > 3 {
+		t.Errorf("ForEachConcurrently() concurrent calls = %d, want at most 3", maxRunning)
+	}
+}
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,20 +10,30 @@ import (
 
 type MockHTTPClient struct {
 	Responses map[string]string
+	// Errors are returned for the URLs instead of a response
+	Errors map[string]error
 }
 
-func (m *MockHTTPClient) SendGetRequest(url string, target interface{}) error {
-	if responseJSON, ok := m.Responses[url]; ok {
-		err := json.NewDecoder(io.NopCloser(strings.NewReader(responseJSON))).Decode(target)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	return fmt.Errorf("unexpected URL: %s", url)
+func (m *MockHTTPClient) SendGetRequest(_ context.Context, url string, target interface{}) error {
+	return m.respond(url, target)
 }
 
-func (m *MockHTTPClient) SendPatchRequest(url string, _ interface{}, target interface{}) error {
+func (m *MockHTTPClient) SendPatchRequest(_ context.Context, url string, _ interface{}, target interface{}) error {
+	return m.respond(url, target)
+}
+
+func (m *MockHTTPClient) SendPostRequest(_ context.Context, url string, _ interface{}, target interface{}) error {
+	return m.respond(url, target)
+}
+
+func (m *MockHTTPClient) SendDeleteRequest(_ context.Context, url string, _ interface{}, target interface{}) error {
+	return m.respond(url, target)
+}
+
+func (m *MockHTTPClient) respond(url string, target interface{}) error {
+	if err, ok := m.Errors[url]; ok {
+		return err
+	}
 	if responseJSON, ok := m.Responses[url]; ok {
 		err := json.NewDecoder(io.NopCloser(strings.NewReader(responseJSON))).Decode(target)
 		if err != nil {
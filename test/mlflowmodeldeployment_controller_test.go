@@ -0,0 +1,212 @@
+package test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mlflowv1beta1 "github.com/Trendyol/mlflow-operator/api/v1beta1"
+	"github.com/Trendyol/mlflow-operator/internal/controller"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow"
+	"github.com/Trendyol/mlflow-operator/internal/mlflow/service"
+	"github.com/Trendyol/mlflow-operator/mock"
+)
+
+// aliasMlflowClients serves the model version an alias points to from a mock MLflow API
+type aliasMlflowClients struct {
+	httpClient *mock.MockHTTPClient
+}
+
+func (c *aliasMlflowClients) MlflowClientFor(_ context.Context, mlflowServerConfig *mlflowv1beta1.MLFlow) (*service.Client, error) {
+	return service.NewClient(mlflowServerConfig, c.httpClient, false), nil
+}
+
+func (c *aliasMlflowClients) pointAlias(modelName, alias, version string) {
+	url := fmt.Sprintf("http://mlflow:5000/api/2.0/mlflow/registered-models/alias?alias=%s&name=%s", alias, modelName)
+	c.httpClient.Responses[url] = fmt.Sprintf(`{"model_version": {"name": %q, "version": %q}}`, modelName, version)
+}
+
+var _ = Describe("MLflowModelDeployment controller", func() {
+	const namespace = "default"
+
+	var (
+		ctx           context.Context
+		reconciler    *controller.MLflowModelDeploymentReconciler
+		mlflowClients *aliasMlflowClients
+	)
+
+	newModelDeployment := func(name string, spec mlflowv1beta1.MLflowModelDeploymentSpec) *mlflowv1beta1.MLflowModelDeployment {
+		spec.MLFlowRef = corev1.LocalObjectReference{Name: "mlflow"}
+		spec.ModelName = "fraud"
+		modelDeployment := &mlflowv1beta1.MLflowModelDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+		}
+		Expect(k8sClient.Create(ctx, modelDeployment)).To(Succeed())
+		return modelDeployment
+	}
+
+	reconcileModelDeployment := func(modelDeployment *mlflowv1beta1.MLflowModelDeployment) (ctrl.Result, error) {
+		return reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(modelDeployment)})
+	}
+
+	getDeployment := func(name string) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, deployment)).To(Succeed())
+		return deployment
+	}
+
+	getModelDeployment := func(modelDeployment *mlflowv1beta1.MLflowModelDeployment) *mlflowv1beta1.MLflowModelDeployment {
+		latest := &mlflowv1beta1.MLflowModelDeployment{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(modelDeployment), latest)).To(Succeed())
+		return latest
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mlflowClients = &aliasMlflowClients{httpClient: &mock.MockHTTPClient{Responses: map[string]string{}}}
+		reconciler = &controller.MLflowModelDeploymentReconciler{
+			K8sClient:           k8sClient,
+			Scheme:              scheme.Scheme,
+			MlflowObjectManager: &mlflow.ObjectManager{Scheme: scheme.Scheme},
+			MlflowClients:       mlflowClients,
+		}
+
+		mlflowServerConfig := &mlflowv1beta1.MLFlow{
+			ObjectMeta: metav1.ObjectMeta{Name: "mlflow", Namespace: namespace},
+			Spec:       mlflowv1beta1.MLFlowSpec{ConfigMapName: "mlflow", ModelImage: "mlflow-model:latest"},
+		}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, mlflowServerConfig))).To(Succeed())
+	})
+
+	It("creates the Deployment serving the model version", func() {
+		replicas := int32(2)
+		modelDeployment := newModelDeployment("fraud-created", mlflowv1beta1.MLflowModelDeploymentSpec{Version: "1", Replicas: &replicas})
+
+		result, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		deployment := getDeployment("fraud-created-model")
+		Expect(metav1.IsControlledBy(deployment, modelDeployment)).To(BeTrue())
+		Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("models:/fraud/1"))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("mlflow-model:latest"))
+
+		status := getModelDeployment(modelDeployment).Status
+		Expect(status.DeploymentName).To(Equal("fraud-created-model"))
+		Expect(status.Version).To(Equal("1"))
+		Expect(status.Replicas).To(Equal(int32(2)))
+	})
+
+	It("updates the Deployment when the spec changes", func() {
+		modelDeployment := newModelDeployment("fraud-updated", mlflowv1beta1.MLflowModelDeploymentSpec{Version: "1"})
+		_, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+
+		modelDeployment = getModelDeployment(modelDeployment)
+		replicas := int32(3)
+		modelDeployment.Spec.Version = "2"
+		modelDeployment.Spec.Replicas = &replicas
+		modelDeployment.Spec.Image = "mlflow-model:2"
+		Expect(k8sClient.Update(ctx, modelDeployment)).To(Succeed())
+
+		_, err = reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment := getDeployment("fraud-updated-model")
+		Expect(*deployment.Spec.Replicas).To(Equal(int32(3)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("models:/fraud/2"))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("mlflow-model:2"))
+		Expect(getModelDeployment(modelDeployment).Status.Version).To(Equal("2"))
+	})
+
+	It("refuses to take over a Deployment it does not control", func() {
+		labels := map[string]string{"app": "fraud-taken"}
+		existingDeployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "fraud-taken-model", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "model", Image: "hand-written:latest"}}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, existingDeployment)).To(Succeed())
+		modelDeployment := newModelDeployment("fraud-taken", mlflowv1beta1.MLflowModelDeploymentSpec{Version: "1"})
+
+		_, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).To(HaveOccurred())
+
+		deployment := getDeployment("fraud-taken-model")
+		Expect(deployment.OwnerReferences).To(BeEmpty())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("hand-written:latest"))
+
+		degraded := meta.FindStatusCondition(getModelDeployment(modelDeployment).Status.Conditions, mlflowv1beta1.ConditionTypeDegraded)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(mlflowv1beta1.ReasonDeploymentFailed))
+	})
+
+	It("leaves the Deployment to the garbage collector once deleted", func() {
+		modelDeployment := newModelDeployment("fraud-deleted", mlflowv1beta1.MLflowModelDeploymentSpec{Version: "1"})
+		_, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+
+		ownerReference := metav1.GetControllerOf(getDeployment("fraud-deleted-model"))
+		Expect(ownerReference).NotTo(BeNil())
+		Expect(ownerReference.UID).To(Equal(getModelDeployment(modelDeployment).UID))
+		Expect(ownerReference.BlockOwnerDeletion).NotTo(BeNil())
+		Expect(*ownerReference.BlockOwnerDeletion).To(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, modelDeployment)).To(Succeed())
+		result, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+	})
+
+	It("rolls the Deployment when the alias moves", func() {
+		mlflowClients.pointAlias("fraud", "champion", "3")
+		modelDeployment := newModelDeployment("fraud-alias", mlflowv1beta1.MLflowModelDeploymentSpec{Alias: "champion"})
+
+		result, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+
+		deployment := getDeployment("fraud-alias-model")
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("models:/fraud@champion"))
+		Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(mlflow.ModelVersionAnnotationKey, "3"))
+		Expect(getModelDeployment(modelDeployment).Status.Version).To(Equal("3"))
+
+		mlflowClients.pointAlias("fraud", "champion", "4")
+		_, err = reconcileModelDeployment(modelDeployment)
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment = getDeployment("fraud-alias-model")
+		Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(mlflow.ModelVersionAnnotationKey, "4"))
+		Expect(getModelDeployment(modelDeployment).Status.Version).To(Equal("4"))
+	})
+
+	It("reports an alias which cannot be resolved", func() {
+		modelDeployment := newModelDeployment("fraud-unresolved", mlflowv1beta1.MLflowModelDeploymentSpec{Alias: "unknown"})
+
+		_, err := reconcileModelDeployment(modelDeployment)
+		Expect(err).To(HaveOccurred())
+
+		degraded := meta.FindStatusCondition(getModelDeployment(modelDeployment).Status.Conditions, mlflowv1beta1.ConditionTypeDegraded)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Reason).To(Equal(mlflowv1beta1.ReasonModelAliasNotResolved))
+	})
+})